@@ -0,0 +1,234 @@
+package datapackage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoSchema is returned when the schema of a resource which does not declare one is requested.
+var ErrNoSchema = errors.New("schema is not declared in the descriptor")
+
+// ResourceError describes why one of the package resources is invalid.
+type ResourceError struct {
+	// Index is the position of the resource in the package descriptor.
+	Index int
+	// Name is the resource name, if available.
+	Name string
+	Err  error
+}
+
+func (e *ResourceError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("resource %d: %v", e.Index, e.Err)
+	}
+	return fmt.Sprintf("resource %d (%s): %v", e.Index, e.Name, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ResourceError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError groups the errors found while processing a descriptor, so all problems
+// can be reported at once.
+type MultiError []error
+
+func (m MultiError) Error() string {
+	msgs := make([]string, len(m))
+	for i, e := range m {
+		msgs[i] = e.Error()
+	}
+	return fmt.Sprintf("%d error(s) found:\n%s", len(m), strings.Join(msgs, "\n"))
+}
+
+// Unwrap returns the grouped errors.
+func (m MultiError) Unwrap() []error {
+	return m
+}
+
+// ObjectsError is returned when the resource data was expected to be a JSON array of objects, but it is not.
+type ObjectsError struct {
+	// Index is the position of the first item which is not an object, or -1 if the data is not an array.
+	Index int
+	// Type is the Go type of the offending value.
+	Type string
+}
+
+func (e *ObjectsError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("data must be a JSON array of objects, got %s", e.Type)
+	}
+	return fmt.Sprintf("data item %d must be a JSON object, got %s", e.Index, e.Type)
+}
+
+// CastError is returned when a cell of a tabular resource can not be cast to the type of its field.
+type CastError struct {
+	// Row is the position of the row, starting at 0 and not counting the header row.
+	Row int
+	// Field is the name of the schema field the cell belongs to.
+	Field string
+	// Value is the raw cell value.
+	Value string
+	Err   error
+}
+
+func (e *CastError) Error() string {
+	return fmt.Sprintf("row %d, field %s: could not cast %q: %v", e.Row, e.Field, e.Value, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *CastError) Unwrap() error {
+	return e.Err
+}
+
+// PrimaryKeyError is returned when a row violates the primary key of the resource schema.
+type PrimaryKeyError struct {
+	// Row is the position of the offending row, starting at 0 and not counting the header row.
+	Row int
+	// Fields are the primary key fields.
+	Fields []string
+	// NullField is the key field holding a null value. If empty, the row repeats the key of row FirstRow.
+	NullField string
+	FirstRow  int
+}
+
+func (e *PrimaryKeyError) Error() string {
+	if e.NullField != "" {
+		return fmt.Sprintf("row %d: primary key field %s MUST NOT be null", e.Row, e.NullField)
+	}
+	return fmt.Sprintf("row %d: primary key %v duplicates row %d", e.Row, e.Fields, e.FirstRow)
+}
+
+// IntegrityError is returned when the resource contents do not match its hash or bytes properties.
+type IntegrityError struct {
+	// Resource is the resource name.
+	Resource string
+	// Property is the mismatching property, either "hash" or "bytes".
+	Property string
+	// Algorithm is the hash algorithm, if Property is "hash".
+	Algorithm string
+	// Expected is the declared value, either the hexadecimal digest or the number of bytes.
+	Expected string
+	// Actual is the value computed from the contents.
+	Actual string
+}
+
+func (e *IntegrityError) Error() string {
+	if e.Property == hashProp {
+		return fmt.Sprintf("%s hash mismatch for resource %s: expected %s, got %s", e.Algorithm, e.Resource, e.Expected, e.Actual)
+	}
+	return fmt.Sprintf("%s mismatch for resource %s: expected %s, got %s", e.Property, e.Resource, e.Expected, e.Actual)
+}
+
+// ForeignKeyError is returned when a foreign key value is not found in the referenced resource.
+type ForeignKeyError struct {
+	// Resource is the name of the resource declaring the foreign key.
+	Resource string
+	// Row is the position of the offending row, starting at 0 and not counting the header row.
+	Row int
+	// Fields are the foreign key fields.
+	Fields []string
+	// Values are the values of the foreign key fields in the offending row.
+	Values []interface{}
+	// Reference is the name of the referenced resource.
+	Reference string
+}
+
+func (e *ForeignKeyError) Error() string {
+	return fmt.Sprintf("resource %s, row %d: %v %v not found in resource %s", e.Resource, e.Row, e.Fields, e.Values, e.Reference)
+}
+
+// Rules reported by ValidationError.
+const (
+	// RuleProfile is violated by descriptors which are not valid against their profile.
+	RuleProfile = "profile"
+	// RuleResourceType is violated by resources which are not JSON objects.
+	RuleResourceType = "resource-type"
+	// RuleNameMissing is violated by resources without name.
+	RuleNameMissing = "name-missing"
+	// RuleNameType is violated by names which are not strings.
+	RuleNameType = "name-type"
+	// RuleNameFormat is violated by names with characters other than lowercase alphanumerics, ".", "-" and "_".
+	RuleNameFormat = "name-format"
+	// RulePathOrData is violated by resources declaring neither path nor data.
+	RulePathOrData = "path-or-data"
+	// RulePathAndData is violated by resources declaring both path and data.
+	RulePathAndData = "path-and-data"
+	// RulePathType is violated by paths which are neither strings nor arrays of strings.
+	RulePathType = "path-type"
+	// RulePathLocation is violated by absolute paths and paths referring to parent directories.
+	RulePathLocation = "path-location"
+	// RulePathScheme is violated by URLs not using the http or https schemes.
+	RulePathScheme = "path-scheme"
+	// RuleMixedPaths is violated by resources mixing URLs and relative paths.
+	RuleMixedPaths = "mixed-paths"
+	// RuleDataType is violated by inline data which is neither a JSON array, object nor string.
+	RuleDataType = "data-type"
+	// RuleDataFormat is violated by inline JSON strings without format or mediatype.
+	RuleDataFormat = "data-format"
+	// RuleDataRows is violated by inline tabular data rows which are neither JSON arrays nor objects.
+	RuleDataRows = "data-rows"
+	// RuleCompression is violated by compression properties other than "gz" and "no".
+	RuleCompression = "compression"
+	// RuleSchemaLoad is violated by schema references which could not be loaded.
+	RuleSchemaLoad = "schema-load"
+	// RuleSchemaFormat is violated by invalid table schemas.
+	RuleSchemaFormat = "schema-format"
+)
+
+// ValidationError describes a descriptor property violating one of the rules of the specification.
+type ValidationError struct {
+	// Field is the JSON pointer to the invalid property, for instance "/resources/0/name".
+	Field string
+	// Rule identifies the violated rule, for instance RuleNameFormat.
+	Rule string
+	// Message describes the violation.
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	f := e.Field
+	if f == "" {
+		f = "/"
+	}
+	return fmt.Sprintf("%s: %s", f, e.Message)
+}
+
+// Unwrap returns the error corresponding to the violated rule (e.g. ErrInvalidPath for RulePathLocation),
+// so callers can check the kind of violation using errors.Is.
+func (e ValidationError) Unwrap() error {
+	return ruleErrors[e.Rule]
+}
+
+// Kinds of violations reported by ValidationError. See ValidationError.Rule for the violated rule.
+var (
+	// ErrMissingName is the error of resources without name.
+	ErrMissingName = errors.New("missing name")
+	// ErrInvalidName is the error of names which are not strings or have invalid characters.
+	ErrInvalidName = errors.New("invalid name")
+	// ErrPathAndData is the error of resources declaring both path and data.
+	ErrPathAndData = errors.New("both path and data")
+	// ErrMissingPathOrData is the error of resources declaring neither path nor data.
+	ErrMissingPathOrData = errors.New("missing path or data")
+	// ErrInvalidPath is the error of invalid paths: wrong types, absolute or parent paths, URLs not
+	// using http or https, and URLs mixed with relative paths.
+	ErrInvalidPath = errors.New("invalid path")
+	// ErrInvalidData is the error of invalid inline data.
+	ErrInvalidData = errors.New("invalid data")
+)
+
+var ruleErrors = map[string]error{
+	RuleNameMissing:  ErrMissingName,
+	RuleNameType:     ErrInvalidName,
+	RuleNameFormat:   ErrInvalidName,
+	RulePathAndData:  ErrPathAndData,
+	RulePathOrData:   ErrMissingPathOrData,
+	RulePathType:     ErrInvalidPath,
+	RulePathLocation: ErrInvalidPath,
+	RulePathScheme:   ErrInvalidPath,
+	RuleMixedPaths:   ErrInvalidPath,
+	RuleDataType:     ErrInvalidData,
+	RuleDataFormat:   ErrInvalidData,
+	RuleDataRows:     ErrInvalidData,
+}
@@ -0,0 +1,289 @@
+package datapackage
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	hashProp         = "hash"
+	bytesProp        = "bytes"
+	defaultHashAlgo  = "md5"
+	hashAlgoSplitter = ":"
+)
+
+// Hash algorithms supported when checking the resource hash property.
+var hashAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// VerifyIntegrity reads the resource contents and checks them against the hash and bytes properties,
+// if present. The hash could be either prefixed by the algorithm (e.g. "sha256:7f83b1...") or a bare
+// hexadecimal digest, which defaults to md5. Contents are streamed as stored, which means compressed
+// contents are not decompressed and the parts of multipart resources are concatenated as they are.
+// Mismatches are reported as *IntegrityError.
+func (r *Resource) VerifyIntegrity() error {
+	hashStr, hasHash := r.descriptor[hashProp].(string)
+	wantBytes, hasBytes, err := parseBytes(r.descriptor[bytesProp])
+	if err != nil {
+		return err
+	}
+	if !hasHash && !hasBytes {
+		return nil
+	}
+	algo, wantDigest, err := parseHash(hashStr)
+	if err != nil {
+		return err
+	}
+	h := hashAlgorithms[algo]()
+	n, err := r.copyContents(h)
+	if err != nil {
+		return err
+	}
+	if hasBytes && n != wantBytes {
+		return &IntegrityError{Resource: r.name, Property: bytesProp, Expected: strconv.FormatInt(wantBytes, 10), Actual: strconv.FormatInt(n, 10)}
+	}
+	if hasHash {
+		if gotDigest := hex.EncodeToString(h.Sum(nil)); gotDigest != wantDigest {
+			return &IntegrityError{Resource: r.name, Property: hashProp, Algorithm: algo, Expected: wantDigest, Actual: gotDigest}
+		}
+	}
+	return nil
+}
+
+// CheckIntegrity checks the resource contents against its hash and bytes properties, as
+// VerifyIntegrity does. It is the resource counterpart of Package.CheckIntegrity.
+func (r *Resource) CheckIntegrity() error {
+	return r.VerifyIntegrity()
+}
+
+// Hash returns the algorithm and the lowercase hexadecimal digest of the resource hash property. Bare
+// digests default to md5. Empty strings are returned if the resource has no hash.
+func (r *Resource) Hash() (algorithm, hex string, err error) {
+	switch h := r.descriptor[hashProp].(type) {
+	case nil:
+		return "", "", nil
+	case string:
+		if h == "" {
+			return "", "", nil
+		}
+		return parseHash(h)
+	default:
+		return "", "", fmt.Errorf("hash property MUST be a string:%v", h)
+	}
+}
+
+// VerifyHash reads the resource contents, as stored, and checks them against the hash property.
+// Relative paths are resolved against basePath or, if empty, against the resource base path.
+// A mismatch is reported as *IntegrityError, while an error is also returned if the resource
+// has no hash.
+func (r *Resource) VerifyHash(basePath string) error {
+	algo, wantDigest, err := r.Hash()
+	if err != nil {
+		return err
+	}
+	if wantDigest == "" {
+		return fmt.Errorf("resource %s has no hash property", r.name)
+	}
+	h := hashAlgorithms[algo]()
+	if _, err := r.withBasePath(basePath).copyContents(h); err != nil {
+		return err
+	}
+	if gotDigest := hex.EncodeToString(h.Sum(nil)); gotDigest != wantDigest {
+		return &IntegrityError{Resource: r.name, Property: hashProp, Algorithm: algo, Expected: wantDigest, Actual: gotDigest}
+	}
+	return nil
+}
+
+// CheckIntegrity checks the integrity of every resource (see Resource.VerifyIntegrity). All
+// resources are checked, and the returned error is a MultiError of *ResourceError values, one
+// per resource failing the check.
+func (p *Package) CheckIntegrity() error {
+	var errs MultiError
+	for i, r := range p.Resources() {
+		if err := r.VerifyIntegrity(); err != nil {
+			errs = append(errs, &ResourceError{Index: i, Name: r.name, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// StatsOption configures UpdateStats.
+type StatsOption func(*statsConfig)
+
+type statsConfig struct {
+	includeRemote bool
+}
+
+// WithRemoteResources makes UpdateStats also download remote resources, which are skipped by default.
+func WithRemoteResources() StatsOption {
+	return func(cfg *statsConfig) {
+		cfg.includeRemote = true
+	}
+}
+
+// UpdateStats computes the size and the digest of the resources contents, as stored, and sets their
+// bytes and hash properties. The algorithm could be "md5", "sha1", "sha256" or "sha512". Md5 hashes
+// are set as bare hexadecimal digests, while the others are prefixed by the algorithm
+// (e.g. "sha256:7f83b1..."). Inline data resources are skipped, as well as remote ones unless
+// WithRemoteResources is passed. The package is only updated if all stats could be computed.
+// Updated resources are replaced, so resources obtained before the update keep their previous
+// descriptors.
+func (p *Package) UpdateStats(algo string, opts ...StatsOption) error {
+	var cfg statsConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	newHash, ok := hashAlgorithms[algo]
+	if !ok {
+		return fmt.Errorf("unsupported hash algorithm:%q", algo)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	rSlice, ok := p.descriptor[resourcePropName].([]interface{})
+	if !ok {
+		return fmt.Errorf("invalid resources property:\"%v\"", p.descriptor[resourcePropName])
+	}
+	type stats struct {
+		bytes int64
+		hash  string
+	}
+	computed := make(map[int]stats, len(p.resources))
+	for i, r := range p.resources {
+		if r.data != nil || (r.remote() && !cfg.includeRemote) {
+			continue
+		}
+		h := newHash()
+		n, err := r.copyContents(h)
+		if err != nil {
+			return fmt.Errorf("error computing stats of resource %s: %w", r.name, err)
+		}
+		digest := hex.EncodeToString(h.Sum(nil))
+		if algo != defaultHashAlgo {
+			digest = algo + hashAlgoSplitter + digest
+		}
+		computed[i] = stats{n, digest}
+	}
+	// Resources previously handed out might be in use, so the stats are set on copies which
+	// replace them.
+	updated := make(map[int]*Resource, len(computed))
+	for i, s := range computed {
+		c, err := p.resources[i].Clone()
+		if err != nil {
+			return err
+		}
+		c.descriptor[bytesProp] = s.bytes
+		c.descriptor[hashProp] = s.hash
+		updated[i] = c
+	}
+	for i, c := range updated {
+		p.resources[i] = c
+		rSlice[i] = c.Descriptor()
+	}
+	return nil
+}
+
+// remote returns true if any of the resource paths, resolved against the resource base path, is an URL.
+// For instance, relative paths of packages loaded from an URL are remote.
+func (r *Resource) remote() bool {
+	if r.open != nil {
+		return false
+	}
+	for _, p := range r.path {
+		if strings.HasPrefix(joinPaths(r.basePath, p), "http") {
+			return true
+		}
+	}
+	return false
+}
+
+// copyContents streams the resource contents, as stored, to w.
+func (r *Resource) copyContents(w io.Writer) (int64, error) {
+	if r.data != nil {
+		rc, err := r.RawRead()
+		if err != nil {
+			return 0, err
+		}
+		defer rc.Close()
+		return io.Copy(w, rc)
+	}
+	var n int64
+	for _, p := range r.path {
+		written, err := r.copyPath(w, p)
+		n += written
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (r *Resource) copyPath(w io.Writer, p string) (int64, error) {
+	if !strings.HasPrefix(p, "http") && r.open == nil && r.basePath != "" {
+		p = joinPaths(r.basePath, p)
+	}
+	var rc io.ReadCloser
+	var err error
+	switch {
+	case strings.HasPrefix(p, "http"):
+		rc, err = r.fetchFunc(context.Background(), p)()
+	case r.open != nil:
+		rc, err = r.open(p)
+	default:
+		rc, err = os.Open(p)
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+	return io.Copy(w, rc)
+}
+
+// parseHash returns the algorithm and the lowercase hexadecimal digest of the passed-in hash property.
+func parseHash(h string) (string, string, error) {
+	if h == "" {
+		return defaultHashAlgo, "", nil
+	}
+	algo, digest := defaultHashAlgo, h
+	if i := strings.Index(h, hashAlgoSplitter); i >= 0 {
+		algo, digest = strings.ToLower(h[:i]), h[i+1:]
+	}
+	if _, ok := hashAlgorithms[algo]; !ok {
+		return "", "", fmt.Errorf("unsupported hash algorithm:%q", algo)
+	}
+	return algo, strings.ToLower(digest), nil
+}
+
+func parseBytes(b interface{}) (int64, bool, error) {
+	switch v := b.(type) {
+	case nil:
+		return 0, false, nil
+	case float64:
+		// JSON numbers are decoded as float64, so integers are checked to have no fractional part.
+		if v == math.Trunc(v) {
+			return int64(v), true, nil
+		}
+	case int:
+		return int64(v), true, nil
+	case int64:
+		return v, true, nil
+	}
+	return 0, false, fmt.Errorf("bytes property MUST be an integer:%v", b)
+}
@@ -0,0 +1,317 @@
+package datapackage
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/frictionlessdata/datapackage-go/validator"
+	"github.com/matryer/is"
+)
+
+func TestResource_VerifyIntegrity(t *testing.T) {
+	contents := "name\nfoo\n"
+	md5Sum := md5.Sum([]byte(contents))
+	md5Hex := hex.EncodeToString(md5Sum[:])
+	sha256Sum := sha256.Sum256([]byte(contents))
+	sha256Hex := hex.EncodeToString(sha256Sum[:])
+	files := map[string]string{"foo.csv": contents}
+	t.Run("Valid", func(t *testing.T) {
+		data := []struct {
+			desc string
+			d    map[string]interface{}
+		}{
+			{"NoProperties", map[string]interface{}{}},
+			{"BareMD5", map[string]interface{}{"hash": md5Hex}},
+			{"MD5Prefix", map[string]interface{}{"hash": "md5:" + md5Hex}},
+			{"SHA256", map[string]interface{}{"hash": "sha256:" + sha256Hex}},
+			{"UpperCase", map[string]interface{}{"hash": "SHA256:" + strings.ToUpper(sha256Hex)}},
+			{"Bytes", map[string]interface{}{"bytes": len(contents)}},
+			{"HashAndBytes", map[string]interface{}{"hash": md5Hex, "bytes": len(contents)}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				d.d["name"], d.d["path"] = "foo", "foo.csv"
+				is.NoErr(newTestResource(t, d.d, files).VerifyIntegrity())
+			})
+		}
+	})
+	t.Run("Mismatch", func(t *testing.T) {
+		data := []struct {
+			desc string
+			d    map[string]interface{}
+			want IntegrityError
+		}{
+			{"Hash", map[string]interface{}{"hash": "sha256:" + md5Hex}, IntegrityError{Resource: "foo", Property: "hash", Algorithm: "sha256", Expected: md5Hex, Actual: sha256Hex}},
+			{"Bytes", map[string]interface{}{"bytes": 3}, IntegrityError{Resource: "foo", Property: "bytes", Expected: "3", Actual: "9"}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				d.d["name"], d.d["path"] = "foo", "foo.csv"
+				err := newTestResource(t, d.d, files).CheckIntegrity()
+				iErr, ok := err.(*IntegrityError)
+				is.True(ok)
+				is.Equal(*iErr, d.want)
+			})
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		data := []struct {
+			desc string
+			d    map[string]interface{}
+			want string
+		}{
+			{"HashMismatch", map[string]interface{}{"hash": "sha256:" + md5Hex}, "expected " + md5Hex + ", got " + sha256Hex},
+			{"BytesMismatch", map[string]interface{}{"bytes": 3}, "expected 3, got 9"},
+			{"UnsupportedAlgorithm", map[string]interface{}{"hash": "crc32:1234"}, "unsupported hash algorithm"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				d.d["name"], d.d["path"] = "foo", "foo.csv"
+				err := newTestResource(t, d.d, files).VerifyIntegrity()
+				is.True(err != nil)
+				is.True(strings.Contains(err.Error(), d.want))
+			})
+		}
+	})
+	t.Run("FractionalBytes", func(t *testing.T) {
+		is := is.New(t)
+		// Skipping the profile validation, which also rejects the descriptor.
+		r := &Resource{descriptor: map[string]interface{}{"name": "foo", "path": "foo.csv", "bytes": 12.7}}
+		err := r.VerifyIntegrity()
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), "bytes property MUST be an integer"))
+	})
+}
+
+func TestResource_Hash(t *testing.T) {
+	data := []struct {
+		desc   string
+		hash   interface{}
+		algo   string
+		digest string
+	}{
+		{"None", nil, "", ""},
+		{"Empty", "", "", ""},
+		{"Bare", "ABC123", "md5", "abc123"},
+		{"SHA256", "sha256:abc123", "sha256", "abc123"},
+		{"MD5", "MD5:abc123", "md5", "abc123"},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			r := &Resource{descriptor: map[string]interface{}{"hash": d.hash}}
+			algo, digest, err := r.Hash()
+			is.NoErr(err)
+			is.Equal(algo, d.algo)
+			is.Equal(digest, d.digest)
+		})
+	}
+	t.Run("Invalid", func(t *testing.T) {
+		for _, h := range []interface{}{1, "crc32:abc123"} {
+			is := is.New(t)
+			_, _, err := (&Resource{descriptor: map[string]interface{}{"hash": h}}).Hash()
+			is.True(err != nil)
+		}
+	})
+}
+
+func TestResource_VerifyHash(t *testing.T) {
+	is := is.New(t)
+	dir, err := ioutil.TempDir("", "datapackage_verifyhash")
+	is.NoErr(err)
+	defer os.RemoveAll(dir)
+	contents := []byte("name\nfoo\n")
+	is.NoErr(ioutil.WriteFile(filepath.Join(dir, "foo.csv"), contents, 0666))
+	md5Sum := md5.Sum(contents)
+	sha256Sum := sha256.Sum256(contents)
+	for _, h := range []string{hex.EncodeToString(md5Sum[:]), "md5:" + hex.EncodeToString(md5Sum[:]), "sha256:" + hex.EncodeToString(sha256Sum[:])} {
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": "foo.csv", "hash": h}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		is.NoErr(r.VerifyHash(dir))
+	}
+
+	// The resource base path is used if none is passed.
+	r, err := NewResource(map[string]interface{}{"name": "foo", "path": "foo.csv", "hash": "sha256:" + hex.EncodeToString(md5Sum[:])}, validator.MustInMemoryRegistry())
+	is.NoErr(err)
+	r.basePath = dir
+	err = r.VerifyHash("")
+	iErr, ok := err.(*IntegrityError)
+	is.True(ok)
+	is.Equal(iErr.Actual, hex.EncodeToString(sha256Sum[:]))
+
+	r, err = NewResource(map[string]interface{}{"name": "foo", "path": "foo.csv"}, validator.MustInMemoryRegistry())
+	is.NoErr(err)
+	is.True(r.VerifyHash(dir) != nil) // No hash.
+}
+
+func TestPackage_CheckIntegrity(t *testing.T) {
+	is := is.New(t)
+	dir, err := ioutil.TempDir("", "datapackage_integrity")
+	is.NoErr(err)
+	defer os.RemoveAll(dir)
+	contents := []byte("name\nfoo\n")
+	sum := sha256.Sum256(contents)
+	hash := "sha256:" + hex.EncodeToString(sum[:])
+	is.NoErr(ioutil.WriteFile(filepath.Join(dir, "good.csv"), contents, 0666))
+	is.NoErr(ioutil.WriteFile(filepath.Join(dir, "corrupted.csv"), []byte("name\nfoa\n"), 0666))
+	is.NoErr(ioutil.WriteFile(filepath.Join(dir, "truncated.csv"), contents[:5], 0666))
+
+	t.Run("Valid", func(t *testing.T) {
+		is := is.New(t)
+		pkg := newTestPackage(t, dir,
+			map[string]interface{}{"name": "good", "path": "good.csv", "hash": hash, "bytes": len(contents)},
+			map[string]interface{}{"name": "nochecks", "path": "corrupted.csv"},
+		)
+		is.NoErr(pkg.CheckIntegrity())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		is := is.New(t)
+		pkg := newTestPackage(t, dir,
+			map[string]interface{}{"name": "corrupted", "path": "corrupted.csv", "hash": hash},
+			map[string]interface{}{"name": "good", "path": "good.csv", "hash": hash},
+			map[string]interface{}{"name": "truncated", "path": "truncated.csv", "bytes": len(contents)},
+		)
+		err := pkg.CheckIntegrity()
+		errs, ok := err.(MultiError)
+		is.True(ok)
+		is.Equal(len(errs), 2)
+		is.Equal(errs[0].(*ResourceError).Name, "corrupted")
+		is.Equal(errs[0].(*ResourceError).Err.(*IntegrityError).Property, "hash")
+		is.Equal(errs[1].(*ResourceError).Index, 2)
+		is.Equal(errs[1].(*ResourceError).Err.(*IntegrityError).Property, "bytes")
+	})
+}
+
+func TestPackage_UpdateStats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "datapackage_stats")
+	if err != nil {
+		t.Fatalf("want:nil got:%q", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "data.csv"), []byte("name\nfoo\n"), 0666); err != nil {
+		t.Fatalf("want:nil got:%q", err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "name\nfoo\n")
+	}))
+	defer ts.Close()
+	resources := []interface{}{
+		map[string]interface{}{"name": "local", "path": "data.csv"},
+		map[string]interface{}{"name": "remote", "path": ts.URL + "/data.csv"},
+		map[string]interface{}{"name": "inline", "data": []interface{}{[]interface{}{"foo"}}},
+	}
+	t.Run("Algorithms", func(t *testing.T) {
+		data := []struct {
+			algo string
+			want string
+		}{
+			{"md5", "c56a926f63a4c36e411c311b855c9daa"},
+			{"sha1", "sha1:6c5be42622341627899657316c0bbc2f11686c5f"},
+			{"sha256", "sha256:0dcff1d2a646b67dd872713f8f34799065b45e3587c4ba6befd0ae7531c275ce"},
+		}
+		for _, d := range data {
+			t.Run(d.algo, func(t *testing.T) {
+				is := is.New(t)
+				pkg := newTestPackage(t, dir, resources...)
+				is.NoErr(pkg.UpdateStats(d.algo))
+				local := pkg.GetResource("local").Descriptor()
+				is.Equal(local["hash"], d.want)
+				is.Equal(local["bytes"], int64(9))
+				is.Equal(pkg.Descriptor()["resources"].([]interface{})[0], local)
+				is.NoErr(pkg.GetResource("local").VerifyIntegrity())
+
+				// Remote and inline resources are skipped by default.
+				_, ok := pkg.GetResource("remote").Descriptor()["hash"]
+				is.True(!ok)
+				_, ok = pkg.GetResource("inline").Descriptor()["hash"]
+				is.True(!ok)
+				is.Equal(len(pkg.Validate()), 0)
+			})
+		}
+	})
+	t.Run("WithRemoteResources", func(t *testing.T) {
+		is := is.New(t)
+		pkg := newTestPackage(t, dir, resources...)
+		is.NoErr(pkg.UpdateStats("md5", WithRemoteResources()))
+		is.Equal(pkg.GetResource("remote").Descriptor()["hash"], "c56a926f63a4c36e411c311b855c9daa")
+	})
+	t.Run("ConcurrentReaders", func(t *testing.T) {
+		is := is.New(t)
+		pkg := newTestPackage(t, dir, resources...)
+		local := pkg.GetResource("local")
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				local.Descriptor()
+				pkg.Descriptor()
+			}()
+		}
+		is.NoErr(pkg.UpdateStats("md5"))
+		wg.Wait()
+		// Resources obtained before the update are left untouched.
+		_, ok := local.Descriptor()["hash"]
+		is.True(!ok)
+		is.Equal(pkg.GetResource("local").Descriptor()["hash"], "c56a926f63a4c36e411c311b855c9daa")
+	})
+	t.Run("RemoteBasePath", func(t *testing.T) {
+		is := is.New(t)
+		var requests int
+		rs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			fmt.Fprint(w, "name\nfoo\n")
+		}))
+		defer rs.Close()
+		pkg, err := New(map[string]interface{}{"resources": []interface{}{
+			map[string]interface{}{"name": "relative", "path": "data.csv"},
+		}}, rs.URL, validator.InMemoryLoader())
+		is.NoErr(err)
+
+		// Relative paths of remote packages are remote as well.
+		is.NoErr(pkg.UpdateStats("md5"))
+		is.Equal(requests, 0)
+		_, ok := pkg.GetResource("relative").Descriptor()["hash"]
+		is.True(!ok)
+
+		is.NoErr(pkg.UpdateStats("md5", WithRemoteResources()))
+		is.Equal(requests, 1)
+		is.Equal(pkg.GetResource("relative").Descriptor()["hash"], "c56a926f63a4c36e411c311b855c9daa")
+	})
+	t.Run("SaveDescriptor", func(t *testing.T) {
+		is := is.New(t)
+		pkg := newTestPackage(t, dir, resources...)
+		is.NoErr(pkg.UpdateStats("sha256"))
+		fName := filepath.Join(dir, "datapackage.json")
+		is.NoErr(pkg.SaveDescriptor(fName))
+		loaded, err := Load(fName, validator.InMemoryLoader())
+		is.NoErr(err)
+		local := loaded.GetResource("local").Descriptor()
+		is.Equal(local["hash"], "sha256:0dcff1d2a646b67dd872713f8f34799065b45e3587c4ba6befd0ae7531c275ce")
+		is.Equal(local["bytes"], 9.0)
+		is.NoErr(loaded.GetResource("local").VerifyIntegrity())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		is := is.New(t)
+		pkg := newTestPackage(t, dir, resources...)
+		is.True(pkg.UpdateStats("crc32") != nil)
+
+		is.NoErr(pkg.AddResource(map[string]interface{}{"name": "missing", "path": "missing.csv"}))
+		is.True(pkg.UpdateStats("md5") != nil)
+		_, ok := pkg.GetResource("local").Descriptor()["hash"]
+		is.True(!ok) // Nothing is updated on errors.
+	})
+}
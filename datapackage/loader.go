@@ -0,0 +1,200 @@
+package datapackage
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/frictionlessdata/datapackage-go/validator"
+)
+
+const remoteFetchTimeout = 30 * time.Second
+
+// defaultHTTPClient is used to fetch remote descriptors, schemas and resource contents
+// when no client is configured.
+var defaultHTTPClient = &http.Client{Timeout: remoteFetchTimeout}
+
+// LoaderOption configures how remote data packages are loaded.
+type LoaderOption func(*loaderConfig)
+
+type loaderConfig struct {
+	client    *http.Client
+	loaders   []validator.RegistryLoader
+	retries   int
+	backoff   time.Duration
+	userAgent string
+	basePath  string
+}
+
+// httpClient returns the configured client, wrapped to retry requests and to set the
+// user agent, if configured.
+func (cfg *loaderConfig) httpClient() *http.Client {
+	if cfg.retries <= 0 && cfg.userAgent == "" {
+		return cfg.client
+	}
+	c := *cfg.client
+	c.Transport = &retryTransport{
+		base:      c.Transport,
+		retries:   cfg.retries,
+		backoff:   cfg.backoff,
+		userAgent: cfg.userAgent,
+	}
+	return &c
+}
+
+// WithHTTPClient sets the client used to fetch the package descriptor, the table schemas it
+// references and the resource contents. It allows setting timeouts, custom transports or
+// authentication. By default, a client with a 30 seconds timeout is used.
+func WithHTTPClient(c *http.Client) LoaderOption {
+	return func(cfg *loaderConfig) {
+		if c != nil {
+			cfg.client = c
+		}
+	}
+}
+
+// WithRegistryLoaders sets the loaders of the profile registry used to validate the package.
+// By default, the registry shipped with the library is used, falling back to remote registries.
+func WithRegistryLoaders(loaders ...validator.RegistryLoader) LoaderOption {
+	return func(cfg *loaderConfig) {
+		cfg.loaders = loaders
+	}
+}
+
+// WithRetries makes GET and HEAD requests to be retried up to n times on transport errors and
+// 5xx responses. The wait before the i-th retry is backoff*2^(i-1). By default, requests
+// are not retried.
+func WithRetries(n int, backoff time.Duration) LoaderOption {
+	return func(cfg *loaderConfig) {
+		cfg.retries = n
+		cfg.backoff = backoff
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests which do not set it.
+func WithUserAgent(ua string) LoaderOption {
+	return func(cfg *loaderConfig) {
+		cfg.userAgent = ua
+	}
+}
+
+// WithBasePath sets the path relative resource paths are resolved against. By default, it is the
+// URL of the descriptor without its last element. It can not be used to load zip bundles, whose
+// relative paths are always resolved within the archive. Loaders which are not configured with
+// options, like New and FromReader, take the base path as an argument instead.
+func WithBasePath(basePath string) LoaderOption {
+	return func(cfg *loaderConfig) {
+		cfg.basePath = basePath
+	}
+}
+
+// FromURL loads the data package (or zip bundle, if the URL ends with .zip) pointed
+// by the passed-in URL. Relative resource paths are resolved against the URL.
+func FromURL(url string, opts ...LoaderOption) (*Package, error) {
+	return FromURLContext(context.Background(), url, opts...)
+}
+
+// FromURLContext is like FromURL, but the package descriptor and the schemas it references
+// are fetched using the passed-in context. Cancelling the context aborts the download, and the
+// returned error wraps ctx.Err().
+func FromURLContext(ctx context.Context, url string, opts ...LoaderOption) (*Package, error) {
+	cfg := loaderConfig{client: defaultHTTPClient}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	isZip := strings.HasSuffix(url, ".zip")
+	if isZip && cfg.basePath != "" {
+		return nil, fmt.Errorf("error loading zip file %s: a base path can not be set, relative paths are resolved within the archive", url)
+	}
+	client := cfg.httpClient()
+	contents, err := fetch(ctx, client, url)
+	if err != nil {
+		return nil, err
+	}
+	if isZip {
+		reader, err := zip.NewReader(bytes.NewReader(contents), int64(len(contents)))
+		if err != nil {
+			return nil, fmt.Errorf("error loading zip file %s: %w", url, err)
+		}
+		return fromZipReader(ctx, reader, client, cfg.loaders...)
+	}
+	var descriptor map[string]interface{}
+	if err := json.Unmarshal(contents, &descriptor); err != nil {
+		return nil, err
+	}
+	basePath := cfg.basePath
+	if basePath == "" {
+		basePath = getBasepath(url)
+	}
+	return newPackage(ctx, descriptor, basePath, nil, client, cfg.loaders...)
+}
+
+// fetch returns the contents of the passed-in URL.
+func fetch(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, url); err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+func checkStatus(resp *http.Response, url string) error {
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("error fetching %s:%q", url, resp.Status)
+	}
+	return nil
+}
+
+// retryTransport retries idempotent requests on transport errors and 5xx responses, waiting
+// an exponentially growing backoff between attempts. It also sets the User-Agent header.
+type retryTransport struct {
+	base      http.RoundTripper
+	retries   int
+	backoff   time.Duration
+	userAgent string
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if t.userAgent != "" && req.Header.Get("User-Agent") == "" {
+		// RoundTrippers must not modify the request.
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return base.RoundTrip(req)
+	}
+	wait := t.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := base.RoundTrip(req)
+		if attempt >= t.retries || (err == nil && resp.StatusCode < http.StatusInternalServerError) {
+			return resp, err
+		}
+		if err == nil {
+			resp.Body.Close()
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
@@ -0,0 +1,256 @@
+package datapackage
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/frictionlessdata/datapackage-go/validator"
+	"github.com/matryer/is"
+)
+
+// countingTransport counts the requests made through it.
+type countingTransport struct {
+	mu   sync.Mutex
+	urls []string
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.urls = append(c.urls, req.URL.Path)
+	c.mu.Unlock()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestFromURL(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data.csv":
+			fmt.Fprint(w, "name\nfoo")
+		case "/schema.json":
+			fmt.Fprint(w, `{"fields": [{"name":"name", "type":"string"}]}`)
+		case "/broken-schema.json":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/broken.json":
+			fmt.Fprint(w, `{"resources": [{"name": "res1", "path": "data.csv", "profile": "tabular-data-resource", "schema": "broken-schema.json"}]}`)
+		case "/pkg.zip":
+			var buf bytes.Buffer
+			zw := zip.NewWriter(&buf)
+			f, _ := zw.Create("datapackage.json")
+			fmt.Fprintf(f, `{"resources": [{"name": "res1", "path": "%s/data.csv", "format": "csv"}]}`, "http://"+r.Host)
+			zw.Close()
+			w.Write(buf.Bytes())
+		default:
+			fmt.Fprint(w, `{"resources": [{"name": "res1", "path": "data.csv", "profile": "tabular-data-resource", "schema": "`+"http://"+r.Host+`/schema.json"}]}`)
+		}
+	}))
+	defer ts.Close()
+	t.Run("WithHTTPClient", func(t *testing.T) {
+		is := is.New(t)
+		transport := &countingTransport{}
+		pkg, err := FromURL(ts.URL+"/datapackage.json", WithHTTPClient(&http.Client{Transport: transport}), WithRegistryLoaders(validator.InMemoryLoader()))
+		is.NoErr(err)
+		contents, err := pkg.GetResource("res1").ReadAll()
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"name"}, {"foo"}})
+		is.Equal(transport.urls, []string{"/datapackage.json", "/schema.json", "/data.csv"})
+
+		// Resources created afterwards also use the client.
+		is.NoErr(pkg.AddResource(map[string]interface{}{"name": "res2", "path": "data.csv", "format": "csv"}))
+		_, err = pkg.GetResource("res2").ReadAll()
+		is.NoErr(err)
+		is.Equal(len(transport.urls), 4)
+	})
+	t.Run("Zip", func(t *testing.T) {
+		is := is.New(t)
+		transport := &countingTransport{}
+		pkg, err := FromURL(ts.URL+"/pkg.zip", WithHTTPClient(&http.Client{Transport: transport}), WithRegistryLoaders(validator.InMemoryLoader()))
+		is.NoErr(err)
+		contents, err := pkg.GetResource("res1").ReadAll()
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"name"}, {"foo"}})
+		is.Equal(transport.urls, []string{"/pkg.zip", "/data.csv"})
+	})
+	t.Run("WithBasePath", func(t *testing.T) {
+		is := is.New(t)
+		pkg, err := FromURL(ts.URL+"/pkg/datapackage.json", WithBasePath(ts.URL), WithRegistryLoaders(validator.InMemoryLoader()))
+		is.NoErr(err)
+		paths, err := pkg.GetResource("res1").FullPaths()
+		is.NoErr(err)
+		is.Equal(paths, []string{ts.URL + "/data.csv"})
+		contents, err := pkg.GetResource("res1").ReadAll()
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"name"}, {"foo"}})
+
+		// Zip bundles resolve relative paths within the archive.
+		transport := &countingTransport{}
+		_, err = FromURL(ts.URL+"/pkg.zip", WithBasePath(ts.URL), WithHTTPClient(&http.Client{Transport: transport}), WithRegistryLoaders(validator.InMemoryLoader()))
+		is.True(err != nil)
+		is.Equal(len(transport.urls), 0)
+	})
+	t.Run("SchemaError", func(t *testing.T) {
+		is := is.New(t)
+		_, err := FromURL(ts.URL+"/broken.json", WithRegistryLoaders(validator.InMemoryLoader()))
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), "broken-schema.json"))
+		is.True(strings.Contains(err.Error(), "res1"))
+	})
+	t.Run("AddResourceSchema", func(t *testing.T) {
+		is := is.New(t)
+		transport := &countingTransport{}
+		pkg, err := FromURL(ts.URL+"/datapackage.json", WithHTTPClient(&http.Client{Transport: transport}), WithRegistryLoaders(validator.InMemoryLoader()))
+		is.NoErr(err)
+
+		// Schemas of resources added afterwards are fetched with the package client.
+		is.NoErr(pkg.AddResource(map[string]interface{}{"name": "res2", "path": "data.csv", "profile": "tabular-data-resource", "schema": "schema.json"}))
+		is.Equal(transport.urls, []string{"/datapackage.json", "/schema.json", "/schema.json"})
+		sch, err := pkg.GetResource("res2").Schema()
+		is.NoErr(err)
+		is.Equal(len(sch["fields"].([]interface{})), 1)
+	})
+	t.Run("DefaultClient", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(defaultHTTPClient.Timeout, 30*time.Second)
+		pkg, err := FromURL(ts.URL+"/datapackage.json", WithRegistryLoaders(validator.InMemoryLoader()))
+		is.NoErr(err)
+		is.Equal(pkg.client, defaultHTTPClient)
+		is.Equal(pkg.GetResource("res1").client, defaultHTTPClient)
+	})
+}
+
+func TestFromURLContext(t *testing.T) {
+	t.Run("Descriptor", func(t *testing.T) {
+		is := is.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"resources": [`)
+			w.(http.Flusher).Flush()
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		}))
+		defer ts.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := FromURLContext(ctx, ts.URL, WithRegistryLoaders(validator.InMemoryLoader()))
+		is.True(err != nil)
+		is.True(time.Since(start) < 2*time.Second)
+	})
+	t.Run("Schema", func(t *testing.T) {
+		is := is.New(t)
+		blocked := make(chan struct{})
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/schema.json" {
+				fmt.Fprint(w, `{"resources": [{"name": "res1", "path": "data.csv", "profile": "tabular-data-resource", "schema": "schema.json"}]}`)
+				return
+			}
+			close(blocked)
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		}))
+		defer ts.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			<-blocked
+			cancel()
+		}()
+		start := time.Now()
+		_, err := FromURLContext(ctx, ts.URL+"/datapackage.json", WithRegistryLoaders(validator.InMemoryLoader()))
+		is.True(errors.Is(err, context.Canceled))
+		is.True(time.Since(start) < 2*time.Second)
+	})
+}
+
+func TestFromURL_Retries(t *testing.T) {
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	var userAgents []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts[r.URL.Path]++
+		n := attempts[r.URL.Path]
+		userAgents = append(userAgents, r.UserAgent())
+		mu.Unlock()
+		switch {
+		case r.URL.Path == "/missing.json":
+			w.WriteHeader(http.StatusNotFound)
+		case n <= 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Path == "/data.csv":
+			fmt.Fprint(w, "name\nfoo")
+		default:
+			fmt.Fprint(w, `{"resources": [{"name": "res1", "path": "data.csv", "format": "csv"}]}`)
+		}
+	}))
+	defer ts.Close()
+	t.Run("FlakyServer", func(t *testing.T) {
+		is := is.New(t)
+		pkg, err := FromURL(ts.URL+"/datapackage.json", WithRetries(3, time.Millisecond), WithUserAgent("datapackage-go-test"), WithRegistryLoaders(validator.InMemoryLoader()))
+		is.NoErr(err)
+		contents, err := pkg.GetResource("res1").ReadAll()
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"name"}, {"foo"}})
+
+		mu.Lock()
+		defer mu.Unlock()
+		is.Equal(attempts["/datapackage.json"], 3)
+		is.Equal(attempts["/data.csv"], 3)
+		for _, ua := range userAgents {
+			is.Equal(ua, "datapackage-go-test")
+		}
+	})
+	t.Run("NotRetryingClientErrors", func(t *testing.T) {
+		is := is.New(t)
+		_, err := FromURL(ts.URL+"/missing.json", WithRetries(3, time.Millisecond), WithRegistryLoaders(validator.InMemoryLoader()))
+		is.True(err != nil)
+
+		mu.Lock()
+		defer mu.Unlock()
+		is.Equal(attempts["/missing.json"], 1)
+	})
+	t.Run("NoRetries", func(t *testing.T) {
+		is := is.New(t)
+		_, err := FromURL(ts.URL+"/other.json", WithRegistryLoaders(validator.InMemoryLoader()))
+		is.True(err != nil)
+
+		mu.Lock()
+		defer mu.Unlock()
+		is.Equal(attempts["/other.json"], 1)
+	})
+}
+
+func TestRetryTransport(t *testing.T) {
+	is := is.New(t)
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+	c := &http.Client{Transport: &retryTransport{retries: 2, backoff: time.Millisecond}}
+
+	// Non-idempotent requests are not retried.
+	resp, err := c.Post(ts.URL, "text/plain", nil)
+	is.NoErr(err)
+	resp.Body.Close()
+	is.Equal(attempts, 1)
+
+	// The last response is returned once retries are exhausted.
+	resp, err = c.Get(ts.URL)
+	is.NoErr(err)
+	resp.Body.Close()
+	is.Equal(resp.StatusCode, http.StatusInternalServerError)
+	is.Equal(attempts, 4)
+}
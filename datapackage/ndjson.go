@@ -0,0 +1,220 @@
+package datapackage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/frictionlessdata/tableschema-go/table"
+)
+
+// ndjsonTable is a table.Table backed by newline-delimited JSON contents, holding one JSON object
+// per line. Blank lines are skipped. http://ndjson.org/
+type ndjsonTable struct {
+	source func() (io.ReadCloser, error)
+	// Column names, usually the schema field names. If empty, they are the keys of the first object.
+	headers []string
+}
+
+// Headers returns the headers of the tabular data. An empty slice is returned if they can not be read.
+func (t *ndjsonTable) Headers() []string {
+	if len(t.headers) > 0 {
+		return t.headers
+	}
+	iter, err := t.iter()
+	if err != nil {
+		return []string{}
+	}
+	defer iter.Close()
+	iter.Next()
+	return iter.headers
+}
+
+// Iter provides a convenient way to iterate over table's data.
+// The iteration process always start at the beginning of the table and
+// is backed by a new reading.
+func (t *ndjsonTable) Iter() (table.Iterator, error) {
+	return t.iter()
+}
+
+func (t *ndjsonTable) iter() (*ndjsonIterator, error) {
+	rc, err := t.source()
+	if err != nil {
+		return nil, err
+	}
+	return &ndjsonIterator{rc: rc, r: bufio.NewReader(rc), headers: t.headers}, nil
+}
+
+// ReadAll reads all rows from the table and return it as strings.
+func (t *ndjsonTable) ReadAll() ([][]string, error) {
+	iter, err := t.iter()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var rows [][]string
+	for iter.Next() {
+		rows = append(rows, iter.Row())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ReadColumn reads a specific column from the table and return it as strings.
+func (t *ndjsonTable) ReadColumn(name string) ([]string, error) {
+	iter, err := t.iter()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var col []string
+	index := -1
+	for iter.Next() {
+		// Headers are only known after reading the first object.
+		if index == -1 {
+			for i, h := range iter.headers {
+				if h == name {
+					index = i
+					break
+				}
+			}
+			if index == -1 {
+				return nil, fmt.Errorf("column name \"%s\" not found in headers", name)
+			}
+		}
+		col = append(col, iter.row[index])
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+// ndjsonIterator reads the newline-delimited JSON objects, mapping their values to the headers.
+// Keys which are not headers are ignored, while missing keys become empty cells.
+type ndjsonIterator struct {
+	rc      io.ReadCloser
+	r       *bufio.Reader
+	headers []string
+	line    int
+	row     []string
+	err     error
+}
+
+func (i *ndjsonIterator) Next() bool {
+	if i.err != nil {
+		return false
+	}
+	for {
+		buf, err := i.r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			i.err = err
+			return false
+		}
+		if len(buf) == 0 && err == io.EOF {
+			return false
+		}
+		i.line++
+		buf = bytes.TrimSpace(buf)
+		if len(buf) == 0 {
+			if err == io.EOF {
+				return false
+			}
+			continue
+		}
+		row, pErr := i.parse(buf)
+		if pErr != nil {
+			i.err = fmt.Errorf("ndjson line %d: %v", i.line, pErr)
+			return false
+		}
+		i.row = row
+		return true
+	}
+}
+
+func (i *ndjsonIterator) parse(buf []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	var obj map[string]interface{}
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, fmt.Errorf("JSON object expected, got null")
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("only one JSON object is allowed per line")
+	}
+	if len(i.headers) == 0 {
+		keys, err := objectKeys(buf)
+		if err != nil {
+			return nil, err
+		}
+		i.headers = keys
+	}
+	row := make([]string, len(i.headers))
+	for j, h := range i.headers {
+		cell, err := ndjsonCell(obj[h])
+		if err != nil {
+			return nil, err
+		}
+		row[j] = cell
+	}
+	return row, nil
+}
+
+// objectKeys returns the keys of the JSON object in buf, in the order they appear.
+func objectKeys(buf []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	var keys []string
+	for dec.More() {
+		k, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k.(string))
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// ndjsonCell converts the JSON value to its string representation, as it would appear in a CSV file.
+// Nulls become empty strings, while arrays and objects are kept as JSON.
+func ndjsonCell(v interface{}) (string, error) {
+	switch c := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return c, nil
+	case json.Number:
+		return c.String(), nil
+	case bool:
+		return strconv.FormatBool(c), nil
+	default:
+		buf, err := json.Marshal(c)
+		return string(buf), err
+	}
+}
+
+func (i *ndjsonIterator) Row() []string {
+	return append([]string{}, i.row...)
+}
+
+func (i *ndjsonIterator) Err() error {
+	return i.err
+}
+
+func (i *ndjsonIterator) Close() error {
+	return i.rc.Close()
+}
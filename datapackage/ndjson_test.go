@@ -0,0 +1,83 @@
+package datapackage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/frictionlessdata/datapackage-go/validator"
+	"github.com/matryer/is"
+)
+
+func TestResource_NDJSON(t *testing.T) {
+	contents := "{\"id\": 1, \"name\": \"foo\", \"tags\": [\"a\"]}\n\n{\"name\": \"bar\", \"id\": 2, \"active\": true}\n{\"id\": 3, \"name\": null}\n"
+	dir, err := ioutil.TempDir("", "datapackage_ndjson")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "data.ndjson"), []byte(contents), 0666); err != nil {
+		t.Fatal(err)
+	}
+	t.Run("Iter", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": "data.ndjson"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		r.basePath = dir
+		is.True(r.Tabular())
+		iter, err := r.Iter()
+		is.NoErr(err)
+		defer iter.Close()
+		var rows [][]string
+		for iter.Next() {
+			rows = append(rows, iter.Row())
+		}
+		is.NoErr(iter.Err())
+		// Columns are the keys of the first object.
+		is.Equal(rows, [][]string{{"1", "foo", `["a"]`}, {"2", "bar", ""}, {"3", "", ""}})
+		tbl, err := r.GetTable()
+		is.NoErr(err)
+		is.Equal(tbl.Headers(), []string{"id", "name", "tags"})
+	})
+	t.Run("Schema", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": "data.ndjson", "profile": "tabular-data-resource", "schema": map[string]interface{}{"fields": []interface{}{
+			map[string]interface{}{"name": "name", "type": "string"},
+			map[string]interface{}{"name": "active", "type": "boolean"},
+			map[string]interface{}{"name": "id", "type": "integer"},
+		}}}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		r.basePath = dir
+		rows, err := r.ReadRows()
+		is.NoErr(err)
+		is.Equal(rows, [][]string{{"foo", "", "1"}, {"bar", "true", "2"}, {"", "", "3"}})
+		objs, err := r.ReadObjectsTyped()
+		is.NoErr(err)
+		is.Equal(objs[1], map[string]interface{}{"name": "bar", "active": true, "id": int64(2)})
+	})
+	t.Run("InlineData", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "data": contents, "format": "ndjson"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		contents, err := r.ReadAll()
+		is.NoErr(err)
+		is.Equal(len(contents), 3)
+		tbl, err := r.GetTable()
+		is.NoErr(err)
+		col, err := tbl.ReadColumn("name")
+		is.NoErr(err)
+		is.Equal(col, []string{"foo", "bar", ""})
+	})
+	t.Run("MalformedLine", func(t *testing.T) {
+		for _, data := range []string{"{\"id\": 1}\n\n{\"id\": 2\n", "{\"id\": 1}\n\n[2]\n", "{\"id\": 1}\n\n{\"id\": 2} {\"id\": 3}\n"} {
+			is := is.New(t)
+			r, err := NewResource(map[string]interface{}{"name": "foo", "data": data, "format": "ndjson"}, validator.MustInMemoryRegistry())
+			is.NoErr(err)
+			_, err = r.ReadAll()
+			is.True(err != nil)
+			is.True(strings.Contains(err.Error(), "line 3"))
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"archive/zip"
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,9 +12,12 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/frictionlessdata/datapackage-go/clone"
 	"github.com/frictionlessdata/datapackage-go/validator"
@@ -34,16 +38,26 @@ const (
 type resourceFactory func(map[string]interface{}) (*Resource, error)
 
 // Package represents a https://specs.frictionlessdata.io/data-package/
+//
+// A Package is safe for concurrent use by multiple goroutines: reads (e.g. GetResource and Descriptor)
+// and changes (e.g. AddResource and RemoveResource) can be interleaved. Resources returned by the package
+// are shared, though, and must be synchronized by the caller if changed (e.g. by Resource.Infer).
 type Package struct {
+	// Guards all fields below.
+	mu        sync.RWMutex
 	resources []*Resource
 
 	basePath    string
+	open        openFunc
+	client      *http.Client
 	descriptor  map[string]interface{}
 	valRegistry validator.Registry
 }
 
 // GetResource return the resource which the passed-in name or nil if the resource is not part of the package.
 func (p *Package) GetResource(name string) *Resource {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	for _, r := range p.resources {
 		if r.name == name {
 			return r
@@ -52,8 +66,46 @@ func (p *Package) GetResource(name string) *Resource {
 	return nil
 }
 
+// ResourceByPath returns the first resource whose path list contains the passed-in path or nil if
+// there is no such resource. Paths are compared as they appear in the descriptor.
+func (p *Package) ResourceByPath(path string) *Resource {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, r := range p.resources {
+		for _, rp := range r.path {
+			if rp == path {
+				return r
+			}
+		}
+	}
+	return nil
+}
+
+// ContainsResource returns true if the package has a resource with the passed-in name.
+func (p *Package) ContainsResource(name string) bool {
+	return p.GetResource(name) != nil
+}
+
+// FindResources returns the resources for which pred returns true, in descriptor order. As with
+// GetResource, the returned resources are the ones held by the package.
+func (p *Package) FindResources(pred func(*Resource) bool) []*Resource {
+	// Not holding the lock while calling pred, which might call package methods.
+	p.mu.RLock()
+	resources := p.resources
+	p.mu.RUnlock()
+	var found []*Resource
+	for _, r := range resources {
+		if pred(r) {
+			found = append(found, r)
+		}
+	}
+	return found
+}
+
 // ResourceNames return a slice containing the name of the resources.
 func (p *Package) ResourceNames() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	s := make([]string, len(p.resources))
 	for i, r := range p.resources {
 		s[i] = r.name
@@ -61,17 +113,253 @@ func (p *Package) ResourceNames() []string {
 	return s
 }
 
-// Resources returns a copy of data package resources.
+// Resources returns the data package resources, in descriptor order. Resources are the ones
+// GetResource returns, while the slice is a copy, so mutating it does not affect the package.
 func (p *Package) Resources() []*Resource {
-	// NOTE: Ignoring errors because we are not changing anything. Just cloning a valid package descriptor and building
-	// its resources.
-	cpy, _ := clone.Descriptor(p.descriptor)
-	res, _ := buildResources(cpy[resourcePropName], p.basePath, p.valRegistry)
-	return res
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([]*Resource(nil), p.resources...)
+}
+
+// License represents a license under which the package is provided.
+// More at https://specs.frictionlessdata.io/data-package/#licenses
+type License struct {
+	Name  string
+	Path  string
+	Title string
+}
+
+// Contributor represents a person or organization which contributed to the package.
+// More at https://specs.frictionlessdata.io/data-package/#contributors
+type Contributor struct {
+	Title        string
+	Path         string
+	Email        string
+	Role         string
+	Organization string
+}
+
+// Source represents a raw source of the package data.
+// More at https://specs.frictionlessdata.io/data-package/#sources
+type Source struct {
+	Title string
+	Path  string
+	Email string
+}
+
+func (p *Package) stringProp(name string) string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	s, _ := p.descriptor[name].(string)
+	return s
+}
+
+// objectsProp returns the objects of the array property with the passed-in name. Values which are not
+// objects are ignored.
+func (p *Package) objectsProp(name string) []map[string]interface{} {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	oSlice, _ := p.descriptor[name].([]interface{})
+	var objs []map[string]interface{}
+	for _, o := range oSlice {
+		if oMap, ok := o.(map[string]interface{}); ok {
+			objs = append(objs, oMap)
+		}
+	}
+	return objs
+}
+
+// setProp sets the descriptor property with the passed-in name, removing it if v is nil.
+func (p *Package) setProp(name string, v interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if v == nil {
+		delete(p.descriptor, name)
+		return
+	}
+	p.descriptor[name] = v
+}
+
+// setStringProp sets the descriptor property with the passed-in name, removing it if s is empty.
+func (p *Package) setStringProp(name, s string) {
+	if s == "" {
+		p.setProp(name, nil)
+		return
+	}
+	p.setProp(name, s)
+}
+
+// objectOf builds a descriptor object out of the passed-in key-value pairs, leaving out empty values.
+func objectOf(kv ...string) map[string]interface{} {
+	o := make(map[string]interface{})
+	for i := 0; i+1 < len(kv); i += 2 {
+		if kv[i+1] != "" {
+			o[kv[i]] = kv[i+1]
+		}
+	}
+	return o
+}
+
+// Name returns the package name or an empty string if it is not set.
+func (p *Package) Name() string {
+	return p.stringProp("name")
+}
+
+// SetName sets the package name, removing it from the descriptor if name is empty. An error is
+// returned if the name has characters other than lowercase alphanumerics, ".", "-" and "_".
+func (p *Package) SetName(name string) error {
+	if name != "" && !nameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid package name %q: it MUST consist only of lowercase alphanumeric characters plus \".\", \"-\" and \"_\"", name)
+	}
+	p.setStringProp("name", name)
+	return nil
+}
+
+// Title returns the package title or an empty string if it is not set.
+func (p *Package) Title() string {
+	return p.stringProp("title")
+}
+
+// SetTitle sets the package title, removing it from the descriptor if title is empty.
+func (p *Package) SetTitle(title string) {
+	p.setStringProp("title", title)
+}
+
+// Description returns the package description or an empty string if it is not set.
+func (p *Package) Description() string {
+	return p.stringProp("description")
+}
+
+// SetDescription sets the package description, removing it from the descriptor if description is empty.
+func (p *Package) SetDescription(description string) {
+	p.setStringProp("description", description)
+}
+
+// Version returns the package version or an empty string if it is not set.
+func (p *Package) Version() string {
+	return p.stringProp("version")
+}
+
+// SetVersion sets the package version, removing it from the descriptor if version is empty.
+func (p *Package) SetVersion(version string) {
+	p.setStringProp("version", version)
+}
+
+// Keywords returns the package keywords. Values which are not strings are ignored.
+func (p *Package) Keywords() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	kSlice, _ := p.descriptor["keywords"].([]interface{})
+	var keywords []string
+	for _, k := range kSlice {
+		if kStr, ok := k.(string); ok {
+			keywords = append(keywords, kStr)
+		}
+	}
+	return keywords
+}
+
+// Licenses returns the package licenses. Values which are not objects are ignored.
+func (p *Package) Licenses() []License {
+	var licenses []License
+	for _, lMap := range p.objectsProp("licenses") {
+		var license License
+		license.Name, _ = lMap["name"].(string)
+		license.Path, _ = lMap["path"].(string)
+		license.Title, _ = lMap["title"].(string)
+		licenses = append(licenses, license)
+	}
+	return licenses
+}
+
+// SetLicenses sets the package licenses, removing them from the descriptor if licenses is empty.
+// Every license MUST have a name or a path, otherwise an error is returned and the package is
+// left unchanged.
+func (p *Package) SetLicenses(licenses []License) error {
+	if len(licenses) == 0 {
+		p.setProp("licenses", nil)
+		return nil
+	}
+	lSlice := make([]interface{}, len(licenses))
+	for i, l := range licenses {
+		if l.Name == "" && l.Path == "" {
+			return fmt.Errorf("license %d: name or path MUST be provided", i)
+		}
+		lSlice[i] = objectOf("name", l.Name, "path", l.Path, "title", l.Title)
+	}
+	p.setProp("licenses", lSlice)
+	return nil
+}
+
+// Contributors returns the package contributors. Values which are not objects are ignored.
+func (p *Package) Contributors() []Contributor {
+	var contributors []Contributor
+	for _, cMap := range p.objectsProp("contributors") {
+		var c Contributor
+		c.Title, _ = cMap["title"].(string)
+		c.Path, _ = cMap["path"].(string)
+		c.Email, _ = cMap["email"].(string)
+		c.Role, _ = cMap["role"].(string)
+		c.Organization, _ = cMap["organization"].(string)
+		contributors = append(contributors, c)
+	}
+	return contributors
+}
+
+// SetContributors sets the package contributors, removing them from the descriptor if contributors
+// is empty. Every contributor MUST have a title, otherwise an error is returned and the package is
+// left unchanged.
+func (p *Package) SetContributors(contributors []Contributor) error {
+	if len(contributors) == 0 {
+		p.setProp("contributors", nil)
+		return nil
+	}
+	cSlice := make([]interface{}, len(contributors))
+	for i, c := range contributors {
+		if c.Title == "" {
+			return fmt.Errorf("contributor %d: title MUST be provided", i)
+		}
+		cSlice[i] = objectOf("title", c.Title, "path", c.Path, "email", c.Email, "role", c.Role, "organization", c.Organization)
+	}
+	p.setProp("contributors", cSlice)
+	return nil
+}
+
+// Sources returns the package sources. Values which are not objects are ignored.
+func (p *Package) Sources() []Source {
+	var sources []Source
+	for _, sMap := range p.objectsProp("sources") {
+		var s Source
+		s.Title, _ = sMap["title"].(string)
+		s.Path, _ = sMap["path"].(string)
+		s.Email, _ = sMap["email"].(string)
+		sources = append(sources, s)
+	}
+	return sources
+}
+
+// SetSources sets the package sources, removing them from the descriptor if sources is empty.
+// Every source MUST have a title, otherwise an error is returned and the package is left unchanged.
+func (p *Package) SetSources(sources []Source) error {
+	if len(sources) == 0 {
+		p.setProp("sources", nil)
+		return nil
+	}
+	sSlice := make([]interface{}, len(sources))
+	for i, s := range sources {
+		if s.Title == "" {
+			return fmt.Errorf("source %d: title MUST be provided", i)
+		}
+		sSlice[i] = objectOf("title", s.Title, "path", s.Path, "email", s.Email)
+	}
+	p.setProp("sources", sSlice)
+	return nil
 }
 
 // AddResource adds a new resource to the package, updating its descriptor accordingly.
 func (p *Package) AddResource(d map[string]interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	resDesc, err := clone.Descriptor(d)
 	if err != nil {
 		return err
@@ -82,7 +370,7 @@ func (p *Package) AddResource(d map[string]interface{}) error {
 		return fmt.Errorf("invalid resources property:\"%v\"", p.descriptor[resourcePropName])
 	}
 	rSlice = append(rSlice, resDesc)
-	r, err := buildResources(rSlice, p.basePath, p.valRegistry)
+	r, err := buildResources(context.Background(), rSlice, p.basePath, p.open, p.client, p.valRegistry)
 	if err != nil {
 		return err
 	}
@@ -91,51 +379,376 @@ func (p *Package) AddResource(d map[string]interface{}) error {
 	return nil
 }
 
-//RemoveResource removes the resource from the package, updating its descriptor accordingly.
-func (p *Package) RemoveResource(name string) {
+// AddResources adds all passed-in resources to the package, updating its descriptor accordingly.
+// The package is only updated if all descriptors are valid, otherwise a MultiError holding one
+// *ResourceError per invalid descriptor is returned. ResourceError.Index is the position of the
+// descriptor in the passed-in slice.
+func (p *Package) AddResources(descriptors []map[string]interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	rSlice, ok := p.descriptor[resourcePropName].([]interface{})
+	if !ok {
+		return fmt.Errorf("invalid resources property:\"%v\"", p.descriptor[resourcePropName])
+	}
+	// Copying to not modify the descriptor in case of errors.
+	newSlice := append([]interface{}{}, rSlice...)
+	var errs MultiError
+	for i, d := range descriptors {
+		resDesc, err := clone.Descriptor(d)
+		if err != nil {
+			errs = append(errs, &ResourceError{Index: i, Err: err})
+			continue
+		}
+		fillResourceDescriptorWithDefaultValues(resDesc)
+		if _, err := newResource(context.Background(), resDesc, p.basePath, p.open, p.client, p.valRegistry); err != nil {
+			name, _ := resDesc[nameProp].(string)
+			errs = append(errs, &ResourceError{Index: i, Name: name, Err: err})
+			continue
+		}
+		newSlice = append(newSlice, resDesc)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	r, err := buildResources(context.Background(), newSlice, p.basePath, p.open, p.client, p.valRegistry)
+	if err != nil {
+		return err
+	}
+	p.descriptor[resourcePropName] = newSlice
+	p.resources = r
+	return nil
+}
+
+// UpdateResource replaces the descriptor of the resource with the passed-in name, keeping its position
+// in the package and updating the package descriptor accordingly. The package is only updated if the
+// new descriptor is valid, otherwise the error will be returned.
+func (p *Package) UpdateResource(name string, d map[string]interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	resDesc, err := clone.Descriptor(d)
+	if err != nil {
+		return err
+	}
+	fillResourceDescriptorWithDefaultValues(resDesc)
+	rSlice, ok := p.descriptor[resourcePropName].([]interface{})
+	if !ok {
+		return fmt.Errorf("invalid resources property:\"%v\"", p.descriptor[resourcePropName])
+	}
 	index := -1
+	for i, r := range p.resources {
+		if r.name == name {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return fmt.Errorf("resource %s not found", name)
+	}
+	newSlice := append([]interface{}{}, rSlice...)
+	newSlice[index] = resDesc
+	r, err := buildResources(context.Background(), newSlice, p.basePath, p.open, p.client, p.valRegistry)
+	if err != nil {
+		return err
+	}
+	p.descriptor[resourcePropName] = newSlice
+	p.resources = r
+	return nil
+}
+
+// ReplaceResource replaces the resource with the passed-in name by a copy of r, keeping its position
+// in the package and updating the package descriptor accordingly.
+func (p *Package) ReplaceResource(name string, r *Resource) error {
+	if r == nil {
+		return fmt.Errorf("resource MUST NOT be nil")
+	}
+	return p.UpdateResource(name, r.descriptor)
+}
+
+// RemoveResource removes the resource from the package, updating its descriptor accordingly.
+// An error is returned if the package has no resource with the passed-in name.
+func (p *Package) RemoveResource(name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	rSlice, ok := p.descriptor[resourcePropName].([]interface{})
 	if !ok {
-		return
+		return fmt.Errorf("invalid resources property:\"%v\"", p.descriptor[resourcePropName])
 	}
+	index := -1
 	for i := range rSlice {
-		r := rSlice[i].(map[string]interface{})
-		if r["name"] == name {
+		if r, ok := rSlice[i].(map[string]interface{}); ok && r[nameProp] == name {
 			index = i
 			break
 		}
 	}
-	if index > -1 {
-		newSlice := append(rSlice[:index], rSlice[index+1:]...)
-		r, err := buildResources(newSlice, p.basePath, p.valRegistry)
-		if err != nil {
-			return
-		}
-		p.descriptor[resourcePropName] = newSlice
-		p.resources = r
+	if index == -1 {
+		return fmt.Errorf("resource %s not found", name)
 	}
+	// Copying to not modify the descriptor in case of errors.
+	newSlice := append(append([]interface{}{}, rSlice[:index]...), rSlice[index+1:]...)
+	r, err := buildResources(context.Background(), newSlice, p.basePath, p.open, p.client, p.valRegistry)
+	if err != nil {
+		return err
+	}
+	p.descriptor[resourcePropName] = newSlice
+	p.resources = r
+	return nil
 }
 
 // Descriptor returns a deep copy of the underlying descriptor which describes the package.
 func (p *Package) Descriptor() map[string]interface{} {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	// Package cescriptor is always valid. Don't need to make the interface overcomplicated.
 	c, _ := clone.Descriptor(p.descriptor)
 	return c
 }
 
+// Clone returns a deep copy of the package, which shares no mutable state with the original.
+func (p *Package) Clone() (*Package, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	cpy, err := clone.Descriptor(p.descriptor)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := buildResources(context.Background(), cpy[resourcePropName], p.basePath, p.open, p.client, p.valRegistry)
+	if err != nil {
+		return nil, err
+	}
+	return &Package{
+		resources:   resources,
+		descriptor:  cpy,
+		valRegistry: p.valRegistry,
+		basePath:    p.basePath,
+		open:        p.open,
+		client:      p.client,
+	}, nil
+}
+
+// Validate checks the package descriptor against the profile it declares and checks every resource,
+// returning all problems found as ValidationError values (see Resource.Validate). The field of
+// resource problems points into the package descriptor, for instance "/resources/0/name".
+// Packages created by this library are valid, but zero-valued packages are not.
+//
+// Registered profiles (e.g. "data-package" or "tabular-data-package") come from the package registry,
+// which defaults to the one shipped with the library. Profile URLs are fetched using the package HTTP
+// client, unless they point to a Frictionless Data profile bundled with the library (e.g.
+// "https://specs.frictionlessdata.io/schemas/data-package.json"). Errors preventing the validation,
+// for instance, profiles which could not be loaded, are returned as they are.
+func (p *Package) Validate() []error {
+	return p.ValidateContext(context.Background())
+}
+
+// ValidateContext is like Validate, but profile URLs are fetched using the passed-in context.
+func (p *Package) ValidateContext(ctx context.Context) []error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	registry := p.valRegistry
+	if registry == nil {
+		var err error
+		if registry, err = validator.NewRegistry(); err != nil {
+			return []error{err}
+		}
+	}
+	client := p.client
+	if client == nil {
+		client = defaultHTTPClient
+	}
+	return validatePackage(ctx, p.descriptor, registry, p.basePath, p.open, client)
+}
+
+// validatePackage checks the package descriptor against its profile and checks every resource
+// descriptor. See Package.Validate.
+func validatePackage(ctx context.Context, descriptor map[string]interface{}, registry validator.Registry, basePath string, open openFunc, client *http.Client) []error {
+	var errs []error
+	reported := map[string]bool{}
+	if err := validate(ctx, descriptor, registry, client); err != nil {
+		sErrs, ok := err.(validator.SchemaErrors)
+		if !ok {
+			return []error{err}
+		}
+		for _, e := range sErrs {
+			reported[e.Pointer] = true
+			errs = append(errs, ValidationError{Field: e.Pointer, Rule: RuleProfile, Message: e.Message})
+		}
+	}
+	rSlice, _ := descriptor[resourcePropName].([]interface{})
+	for i, rI := range rSlice {
+		prefix := fmt.Sprintf("/%s/%d", resourcePropName, i)
+		rDesc, ok := rI.(map[string]interface{})
+		if !ok {
+			if !reported[prefix] {
+				errs = append(errs, ValidationError{Field: prefix, Rule: RuleResourceType, Message: fmt.Sprintf("resources must be a json object. got:%v", rI)})
+			}
+			continue
+		}
+		name, _ := rDesc[nameProp].(string)
+		r := &Resource{descriptor: rDesc, name: name, basePath: basePath, open: open, client: client}
+		// Problems the package profile has already reported are skipped.
+		for _, e := range r.validate(registry) {
+			e.Field = prefix + e.Field
+			if !reported[e.Field] {
+				errs = append(errs, e)
+			}
+		}
+	}
+	return errs
+}
+
+// Valid returns true if Validate finds no problems.
+func (p *Package) Valid() bool {
+	return len(p.Validate()) == 0
+}
+
+// Matches the URLs of the profiles published by Frictionless Data, capturing the profile identifier.
+var frictionlessProfileURL = regexp.MustCompile(`^https?://(?:specs\.)?frictionlessdata\.io/schemas/([a-z-]+)\.json$`)
+
+// ValidateForeignKeys checks the foreign keys declared by the resource schemas, reading the resources
+// contents (see Resource.ReadObjectsTyped). Every value of the foreign key fields MUST be found in the
+// reference fields of the referenced resource, which is the resource itself if the reference resource
+// is empty. Rows with null foreign key values are skipped. All violations are reported at once, as a
+// MultiError of *ForeignKeyError values.
+func (p *Package) ValidateForeignKeys() error {
+	// Not holding the lock while reading the resources contents.
+	p.mu.RLock()
+	resources := p.resources
+	p.mu.RUnlock()
+	byName := make(map[string]*Resource, len(resources))
+	for _, r := range resources {
+		byName[r.name] = r
+	}
+	// Objects of the resources read so far.
+	contents := map[string][]map[string]interface{}{}
+	read := func(r *Resource) ([]map[string]interface{}, error) {
+		if objs, ok := contents[r.name]; ok {
+			return objs, nil
+		}
+		objs, err := r.ReadObjectsTyped()
+		if err != nil {
+			return nil, fmt.Errorf("error reading resource %s: %w", r.name, err)
+		}
+		contents[r.name] = objs
+		return objs, nil
+	}
+	var errs MultiError
+	for _, r := range resources {
+		sch, err := r.Schema()
+		if err != nil {
+			return err
+		}
+		fks, err := parseForeignKeys(sch)
+		if err != nil {
+			return fmt.Errorf("invalid foreign keys of resource %s: %w", r.name, err)
+		}
+		for _, fk := range fks {
+			ref := r
+			if fk.resource != "" {
+				if ref = byName[fk.resource]; ref == nil {
+					return fmt.Errorf("resource %s references resource %s, which does not exist", r.name, fk.resource)
+				}
+			}
+			refObjs, err := read(ref)
+			if err != nil {
+				return err
+			}
+			keys := map[string]bool{}
+			for _, obj := range refObjs {
+				if key, ok := keyOf(obj, fk.refFields); ok {
+					keys[key] = true
+				}
+			}
+			objs, err := read(r)
+			if err != nil {
+				return err
+			}
+			for i, obj := range objs {
+				key, ok := keyOf(obj, fk.fields)
+				if ok && !keys[key] {
+					values := make([]interface{}, len(fk.fields))
+					for j, f := range fk.fields {
+						values[j] = obj[f]
+					}
+					errs = append(errs, &ForeignKeyError{Resource: r.name, Row: i, Fields: fk.fields, Values: values, Reference: ref.name})
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// keyOf returns the key formed by the values of the passed-in fields, or false if any of them is null.
+func keyOf(obj map[string]interface{}, fields []string) (string, bool) {
+	vals := make([]interface{}, len(fields))
+	for i, f := range fields {
+		if obj[f] == nil {
+			return "", false
+		}
+		vals[i] = obj[f]
+	}
+	return fmt.Sprintf("%#v", vals), true
+}
+
 // Update the package with the passed-in descriptor. The package will only be updated if the
 // the new descriptor is valid, otherwise the error will be returned.
 func (p *Package) Update(newDescriptor map[string]interface{}, loaders ...validator.RegistryLoader) error {
-	newP, err := New(newDescriptor, p.basePath, loaders...)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	newP, err := newPackage(context.Background(), newDescriptor, p.basePath, p.open, p.client, loaders...)
 	if err != nil {
 		return err
 	}
-	*p = *newP
+	p.replace(newP)
 	return nil
 }
 
-func (p *Package) write(w io.Writer) error {
-	b, err := json.MarshalIndent(p.descriptor, "", "  ")
+// replace replaces the package state by the newP state. The caller must hold the write lock.
+func (p *Package) replace(newP *Package) {
+	p.resources = newP.resources
+	p.descriptor = newP.descriptor
+	p.valRegistry = newP.valRegistry
+	p.basePath = newP.basePath
+	p.open = newP.open
+	p.client = newP.client
+}
+
+// Infer runs the inference process on every package resource which misses a format or
+// a schema, updating the package descriptor accordingly. Properties which are already set
+// in the descriptor are not overwritten. The package is only updated if all resources could be
+// inferred. Inferred resources are replaced, so resources obtained before the inference keep their
+// previous descriptors.
+func (p *Package) Infer() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	rSlice, ok := p.descriptor[resourcePropName].([]interface{})
+	if !ok {
+		return fmt.Errorf("invalid resources property:\"%v\"", p.descriptor[resourcePropName])
+	}
+	inferred := make(map[int]*Resource, len(p.resources))
+	for i, r := range p.resources {
+		if r.descriptor[formatProp] != nil && r.descriptor[schemaProp] != nil {
+			continue
+		}
+		c, err := r.Clone()
+		if err != nil {
+			return err
+		}
+		if err := c.Infer(); err != nil {
+			return err
+		}
+		inferred[i] = c
+	}
+	for i, c := range inferred {
+		p.resources[i] = c
+		rSlice[i] = c.Descriptor()
+	}
+	return nil
+}
+
+func writeDescriptor(w io.Writer, d map[string]interface{}) error {
+	b, err := json.MarshalIndent(d, "", "  ")
 	if err != nil {
 		return err
 	}
@@ -146,22 +759,162 @@ func (p *Package) write(w io.Writer) error {
 	return nil
 }
 
+// ToJSON writes the data package descriptor as JSON to the passed-in io.Writer. If indent is
+// true, the output is indented using two spaces, which is handy for human-readable
+// datapackage.json files.
+func (p *Package) ToJSON(w io.Writer, indent bool) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	enc := json.NewEncoder(w)
+	if indent {
+		enc.SetIndent("", "  ")
+	}
+	return enc.Encode(p.descriptor)
+}
+
+// MarshalJSON returns the JSON encoding of the data package descriptor.
+func (p *Package) MarshalJSON() ([]byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return json.Marshal(p.descriptor)
+}
+
+// UnmarshalJSON replaces the package by the one described by the passed-in JSON descriptor,
+// which is validated using the default registry. The package is only updated if the descriptor
+// is valid, otherwise the error will be returned. Relative paths keep being resolved from
+// the package base path, which is empty for zero-valued packages.
+func (p *Package) UnmarshalJSON(b []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var descriptor map[string]interface{}
+	if err := json.Unmarshal(b, &descriptor); err != nil {
+		return err
+	}
+	newP, err := newPackage(context.Background(), descriptor, p.basePath, p.open, p.client)
+	if err != nil {
+		return err
+	}
+	p.replace(newP)
+	return nil
+}
+
 // SaveDescriptor saves the data package descriptor to the passed-in file path.
 // It create creates the named file with mode 0666 (before umask), truncating
 // it if it already exists.
 func (p *Package) SaveDescriptor(path string) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return saveDescriptor(path, p.descriptor)
+}
+
+func saveDescriptor(path string, d map[string]interface{}) error {
 	f, err := os.Create(path)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
-	return p.write(f)
+	return writeDescriptor(f, d)
+}
+
+// SaveToDirectory saves the data package descriptor and the contents of its local resources
+// to the passed-in directory, which is created if it does not exist. The descriptor is saved
+// as datapackage.json and resource contents are saved to their paths, relative to the directory.
+// Remote resources are not downloaded, their URLs are kept in the descriptor. Inlined data is
+// saved to a file named after the resource and the saved descriptor points to this file. A numeric
+// suffix is added to the file name if it is already taken (e.g. by datapackage.json).
+func (p *Package) SaveToDirectory(dir string) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	d, err := clone.Descriptor(p.descriptor)
+	if err != nil {
+		return err
+	}
+	rSlice, ok := d[resourcePropName].([]interface{})
+	if !ok {
+		return fmt.Errorf("invalid resources property:\"%v\"", d[resourcePropName])
+	}
+	// Files inlined data is saved to must not overwrite the descriptor nor other resources files.
+	taken := map[string]bool{descriptorFileNameWithinZip: true}
+	for _, r := range p.resources {
+		for _, rp := range r.relativeFiles() {
+			taken[path.Clean(rp)] = true
+		}
+	}
+	for i, r := range p.resources {
+		if r.data != nil {
+			fName, contents, err := inlineDataFile(r)
+			if err != nil {
+				return err
+			}
+			fName = uniqueFileName(fName, taken)
+			taken[fName] = true
+			if err := ioutil.WriteFile(filepath.Join(dir, fName), contents, 0666); err != nil {
+				return err
+			}
+			rDesc := rSlice[i].(map[string]interface{})
+			delete(rDesc, dataProp)
+			rDesc[pathProp] = fName
+			continue
+		}
+		for _, rp := range r.relativeFiles() {
+			c, err := r.readPath(rp)
+			if err != nil {
+				return err
+			}
+			fPath := filepath.Join(dir, filepath.FromSlash(rp))
+			if err := os.MkdirAll(filepath.Dir(fPath), os.ModePerm); err != nil {
+				return err
+			}
+			if err := ioutil.WriteFile(fPath, c, 0666); err != nil {
+				return err
+			}
+		}
+	}
+	f, err := os.Create(filepath.Join(dir, descriptorFileNameWithinZip))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeDescriptor(f, d)
+}
+
+// uniqueFileName returns fName if it is not taken, or else fName with a numeric suffix added
+// before the extension (e.g. "datapackage_1.json").
+func uniqueFileName(fName string, taken map[string]bool) string {
+	ext := path.Ext(fName)
+	base := strings.TrimSuffix(fName, ext)
+	for i := 1; taken[fName]; i++ {
+		fName = fmt.Sprintf("%s_%d%s", base, i, ext)
+	}
+	return fName
+}
+
+// inlineDataFile returns the file name and contents used to save inlined data. JSON objects and arrays
+// are saved as JSON. Data strings are saved as is, using the resource format as file extension.
+func inlineDataFile(r *Resource) (string, []byte, error) {
+	if s, ok := r.data.(string); ok {
+		ext := jsonFormat
+		if f, ok := r.descriptor[formatProp].(string); ok && f != "" {
+			ext = f
+		}
+		return r.name + "." + ext, []byte(s), nil
+	}
+	b, err := json.MarshalIndent(r.data, "", "  ")
+	if err != nil {
+		return "", nil, err
+	}
+	return r.name + "." + jsonFormat, b, nil
 }
 
 // Zip saves a zip-compressed file containing the package descriptor and all resource data.
 // It create creates the named file with mode 0666 (before umask), truncating
 // it if it already exists.
 func (p *Package) Zip(path string) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	dir, err := ioutil.TempDir("", "datapackage_zip")
 	if err != nil {
 		return err
@@ -170,14 +923,14 @@ func (p *Package) Zip(path string) error {
 
 	// Saving descriptor.
 	descriptorPath := filepath.Join(dir, descriptorFileNameWithinZip)
-	if err := p.SaveDescriptor(descriptorPath); err != nil {
+	if err := saveDescriptor(descriptorPath, p.descriptor); err != nil {
 		return err
 	}
 	// Downloading resources.
 	fPaths := []string{descriptorPath}
 	for _, r := range p.resources {
 		for _, p := range r.path {
-			c, err := read(filepath.Join(r.basePath, p))
+			c, err := r.readPath(p)
 			if err != nil {
 				return err
 			}
@@ -196,6 +949,38 @@ func (p *Package) Zip(path string) error {
 	return zipFiles(path, dir, fPaths)
 }
 
+// WriteZip writes a zip archive containing the package descriptor (datapackage.json, at the root
+// of the archive) and the contents of all resources which have relative paths. Remote resources
+// are not downloaded, their URLs are kept in the descriptor.
+func (p *Package) WriteZip(w io.Writer) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	zipWriter := zip.NewWriter(w)
+	f, err := zipWriter.Create(descriptorFileNameWithinZip)
+	if err != nil {
+		return err
+	}
+	if err := writeDescriptor(f, p.descriptor); err != nil {
+		return err
+	}
+	for _, r := range p.resources {
+		for _, rp := range r.relativeFiles() {
+			c, err := r.readPath(rp)
+			if err != nil {
+				return err
+			}
+			f, err := zipWriter.Create(path.Clean(filepath.ToSlash(rp)))
+			if err != nil {
+				return err
+			}
+			if _, err := f.Write(c); err != nil {
+				return err
+			}
+		}
+	}
+	return zipWriter.Close()
+}
+
 func zipFiles(filename string, basePath string, files []string) error {
 	newfile, err := os.Create(filename)
 	if err != nil {
@@ -239,24 +1024,32 @@ func zipFiles(filename string, basePath string, files []string) error {
 
 // New creates a new data package based on the descriptor.
 func New(descriptor map[string]interface{}, basePath string, loaders ...validator.RegistryLoader) (*Package, error) {
+	return newPackage(context.Background(), descriptor, basePath, nil, nil, loaders...)
+}
+
+// newPackage creates a package whose relative paths are opened with open and remote paths are fetched
+// with client. If nil, the file system and defaultHTTPClient are used, respectively. Remote schemas
+// referenced by the descriptor are fetched using ctx.
+func newPackage(ctx context.Context, descriptor map[string]interface{}, basePath string, open openFunc, client *http.Client, loaders ...validator.RegistryLoader) (*Package, error) {
 	cpy, err := clone.Descriptor(descriptor)
 	if err != nil {
 		return nil, err
 	}
 	fillPackageDescriptorWithDefaultValues(cpy)
-	loadPackageSchemas(cpy)
-	profile, ok := cpy[profilePropName].(string)
-	if !ok {
-		return nil, fmt.Errorf("%s property MUST be a string", profilePropName)
+	if client == nil {
+		client = defaultHTTPClient
+	}
+	if err := loadPackageSchemas(ctx, cpy, basePath, open, client); err != nil {
+		return nil, err
 	}
 	registry, err := validator.NewRegistry(loaders...)
 	if err != nil {
 		return nil, err
 	}
-	if err := validator.Validate(cpy, profile, registry); err != nil {
+	if err := validate(ctx, cpy, registry, client); err != nil {
 		return nil, err
 	}
-	resources, err := buildResources(cpy[resourcePropName], basePath, registry)
+	resources, err := buildResources(ctx, cpy[resourcePropName], basePath, open, client, registry)
 	if err != nil {
 		return nil, err
 	}
@@ -265,9 +1058,124 @@ func New(descriptor map[string]interface{}, basePath string, loaders ...validato
 		descriptor:  cpy,
 		valRegistry: registry,
 		basePath:    basePath,
+		open:        open,
+		client:      client,
 	}, nil
 }
 
+// Validate checks whether the passed-in descriptor is valid against the profile it declares
+// (data-package, if the profile property is not set) and checks every resource descriptor, as
+// Package.Validate does. Profiles are loaded from the passed-in registry loaders, which default
+// to the registry shipped with the library. If the descriptor is not valid, the returned error
+// is a MultiError listing all problems found as ValidationError values.
+func Validate(descriptor map[string]interface{}, loaders ...validator.RegistryLoader) error {
+	report, err := NewValidationReport(descriptor, loaders...)
+	if err != nil {
+		return err
+	}
+	if !report.Valid() {
+		errs := make(MultiError, len(report.Errors))
+		for i, e := range report.Errors {
+			errs[i] = e
+		}
+		return errs
+	}
+	return nil
+}
+
+// ValidationReport lists the problems found in a data package descriptor.
+type ValidationReport struct {
+	// Errors lists the problems found. Their fields are JSON pointers relative to the package
+	// descriptor root.
+	Errors []ValidationError
+}
+
+// Valid returns true if no problems were found.
+func (r ValidationReport) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// NewValidationReport checks the passed-in descriptor as Validate does, collecting all problems
+// found. The returned error is only non-nil if the validation could not be performed, for
+// instance, if a profile could not be loaded.
+func NewValidationReport(descriptor map[string]interface{}, loaders ...validator.RegistryLoader) (ValidationReport, error) {
+	var report ValidationReport
+	cpy, err := clone.Descriptor(descriptor)
+	if err != nil {
+		return report, err
+	}
+	fillPackageDescriptorWithDefaultValues(cpy)
+	registry, err := validator.NewRegistry(loaders...)
+	if err != nil {
+		return report, err
+	}
+	for _, err := range validatePackage(context.Background(), cpy, registry, "", nil, defaultHTTPClient) {
+		vErr, ok := err.(ValidationError)
+		if !ok {
+			return ValidationReport{}, err
+		}
+		report.Errors = append(report.Errors, vErr)
+	}
+	return report, nil
+}
+
+// validate checks the package descriptor against the profile it declares. Registered profiles come
+// from the registry, while profile URLs are fetched using ctx and client, unless they point to a
+// Frictionless Data profile bundled with the library or the registry has them cached.
+func validate(ctx context.Context, descriptor map[string]interface{}, registry validator.Registry, client *http.Client) error {
+	profile, ok := descriptor[profilePropName].(string)
+	if !ok {
+		return ValidationError{Field: "/" + profilePropName, Rule: RuleProfile, Message: fmt.Sprintf("%s property MUST be a string", profilePropName)}
+	}
+	d := withoutDialectReferences(descriptor)
+	if m := frictionlessProfileURL.FindStringSubmatch(profile); m != nil {
+		if buf, err := validator.LoadBundledProfile(m[1]); err == nil {
+			v, err := validator.NewFromReader(profile, bytes.NewReader(buf))
+			if err != nil {
+				return err
+			}
+			return v.Validate(d)
+		}
+	}
+	if strings.HasPrefix(profile, "http") {
+		// Profiles cached by the registry are not fetched again.
+		v, err := validator.ResolveProfile(registry, profile, func() (validator.DescriptorValidator, error) {
+			buf, err := fetch(ctx, client, profile)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching profile %s: %w", profile, err)
+			}
+			return validator.NewFromReader(profile, bytes.NewReader(buf))
+		})
+		if err != nil {
+			return err
+		}
+		return v.Validate(d)
+	}
+	return validator.Validate(d, profile, registry)
+}
+
+// withoutDialectReferences returns a shallow copy of the package descriptor d where resource dialects
+// referenced by path or URL are left out (see withoutDialectReference).
+func withoutDialectReferences(d map[string]interface{}) map[string]interface{} {
+	rSlice, ok := d[resourcePropName].([]interface{})
+	if !ok {
+		return d
+	}
+	cpy := make(map[string]interface{}, len(d))
+	for k, v := range d {
+		cpy[k] = v
+	}
+	newSlice := make([]interface{}, len(rSlice))
+	for i, rI := range rSlice {
+		newSlice[i] = rI
+		if rDesc, ok := rI.(map[string]interface{}); ok {
+			newSlice[i] = withoutDialectReference(rDesc)
+		}
+	}
+	cpy[resourcePropName] = newSlice
+	return cpy
+}
+
 // FromReader creates a data package from an io.Reader.
 func FromReader(r io.Reader, basePath string, loaders ...validator.RegistryLoader) (*Package, error) {
 	b, err := ioutil.ReadAll(bufio.NewReader(r))
@@ -296,83 +1204,191 @@ func Load(path string, loaders ...validator.RegistryLoader) (*Package, error) {
 	if !strings.HasSuffix(path, ".zip") {
 		return FromReader(bytes.NewBuffer(contents), getBasepath(path), loaders...)
 	}
-	// Special case for zip paths. BasePath will be the temporary directory.
-	dir, err := ioutil.TempDir("", "datapackage_decompress")
+	// Special case for zip paths.
+	reader, err := zip.NewReader(bytes.NewReader(contents), int64(len(contents)))
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := FromZipReader(reader, loaders...)
+	if err != nil {
+		return nil, fmt.Errorf("error loading zip file %s: %w", path, err)
+	}
+	return pkg, nil
+}
+
+// FromZipReader creates a data package from a zip archive, which must contain the package
+// descriptor in a file called datapackage.json, at the root of the archive. Relative resource
+// paths are resolved within the archive: resource contents are read directly from the
+// archive entries, nothing is written to the file system.
+func FromZipReader(r *zip.Reader, loaders ...validator.RegistryLoader) (*Package, error) {
+	return fromZipReader(context.Background(), r, nil, loaders...)
+}
+
+func fromZipReader(ctx context.Context, r *zip.Reader, client *http.Client, loaders ...validator.RegistryLoader) (*Package, error) {
+	open := zipOpenFunc(r)
+	rc, err := open(descriptorFileNameWithinZip)
 	if err != nil {
 		return nil, err
 	}
-	fNames, err := unzip(path, dir)
+	defer rc.Close()
+	b, err := ioutil.ReadAll(rc)
 	if err != nil {
 		return nil, err
 	}
-	if _, ok := fNames[descriptorFileNameWithinZip]; ok {
-		return Load(filepath.Join(dir, descriptorFileNameWithinZip), loaders...)
+	var descriptor map[string]interface{}
+	if err := json.Unmarshal(b, &descriptor); err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("zip file %s does not contain a file called %s", path, descriptorFileNameWithinZip)
+	return newPackage(ctx, descriptor, "", open, client, loaders...)
 }
 
-func getBasepath(p string) string {
-	u, err := url.Parse(p)
+// zipOpenFunc returns an openFunc which opens the entries of the passed-in zip archive.
+func zipOpenFunc(r *zip.Reader) openFunc {
+	files := make(map[string]*zip.File, len(r.File))
+	for _, f := range r.File {
+		files[path.Clean(f.Name)] = f
+	}
+	return func(name string) (io.ReadCloser, error) {
+		f, ok := files[path.Clean(filepath.ToSlash(name))]
+		if !ok {
+			return nil, fmt.Errorf("zip archive does not contain a file called %s", name)
+		}
+		return f.Open()
+	}
+}
+
+// SlugifyResourceNames returns a copy of the passed-in package descriptor where the names of
+// all resources are slugified (see SlugifyName). It allows loading packages whose resource
+// names do not follow the spec, for instance TitleCase names, instead of rejecting them.
+func SlugifyResourceNames(descriptor map[string]interface{}) (map[string]interface{}, error) {
+	cpy, err := clone.Descriptor(descriptor)
 	if err != nil {
-		return filepath.Dir(p)
+		return nil, err
 	}
-	if u.Path == "" {
-		u.Path = "/"
+	rSlice, _ := cpy[resourcePropName].([]interface{})
+	for _, rI := range rSlice {
+		rDesc, ok := rI.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, ok := rDesc[nameProp].(string); ok {
+			rDesc[nameProp] = SlugifyName(name)
+		}
 	}
-	u.Path = filepath.Dir(u.Path)
-	return u.String()
+	return cpy, nil
 }
 
-func read(path string) ([]byte, error) {
-	if strings.HasPrefix(path, "http") {
-		resp, err := http.Get(path)
+// InferFromDir creates a package with one resource per data file found in the passed-in
+// directory, walking it recursively. If patterns are passed, only files whose names
+// match at least one of them (see filepath.Match) are considered. Hidden files and the
+// package descriptor itself are skipped. Resource names are derived from the file paths
+// and the format and schema of each resource are inferred (see Package.Infer).
+func InferFromDir(dir string, patterns ...string) (*Package, error) {
+	var resources []interface{}
+	names := make(map[string]struct{})
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if p != dir && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
 		}
-		defer resp.Body.Close()
-		buf, err := ioutil.ReadAll(resp.Body)
+		if info.IsDir() || info.Name() == descriptorFileNameWithinZip {
+			return nil
+		}
+		ok, err := matchAny(info.Name(), patterns)
+		if err != nil || !ok {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		return buf, nil
-	}
-	buf, err := ioutil.ReadFile(path)
+		rel = filepath.ToSlash(rel)
+		resources = append(resources, map[string]interface{}{
+			"name": uniqueName(nameFromPath(rel), names),
+			"path": rel,
+		})
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return buf, nil
-}
-
-func unzip(archive, basePath string) (map[string]struct{}, error) {
-	fileNames := make(map[string]struct{})
-	reader, err := zip.OpenReader(archive)
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("no data files found in %s", dir)
+	}
+	pkg, err := New(map[string]interface{}{resourcePropName: resources}, dir)
 	if err != nil {
 		return nil, err
 	}
-	if err := os.MkdirAll(basePath, os.ModePerm); err != nil {
+	if err := pkg.Infer(); err != nil {
 		return nil, err
 	}
-	for _, file := range reader.File {
-		fileNames[file.Name] = struct{}{}
-		path := filepath.Join(basePath, file.Name)
-		if filepath.Dir(file.Name) != "." {
-			os.MkdirAll(filepath.Join(basePath, filepath.Dir(file.Name)), os.ModePerm)
-		}
-		fileReader, err := file.Open()
+	return pkg, nil
+}
+
+func matchAny(name string, patterns []string) (bool, error) {
+	if len(patterns) == 0 {
+		return true, nil
+	}
+	for _, pattern := range patterns {
+		ok, err := filepath.Match(pattern, name)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
-		defer fileReader.Close()
-		targetFile, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
-		if err != nil {
-			return nil, err
+		if ok {
+			return true, nil
 		}
-		defer targetFile.Close()
-		if _, err := io.Copy(targetFile, fileReader); err != nil {
-			return nil, err
+	}
+	return false, nil
+}
+
+// nameFromPath derives a resource name from a relative file path, dropping the extension
+// and slugifying the rest (see SlugifyName).
+func nameFromPath(p string) string {
+	n := SlugifyName(strings.TrimSuffix(p, path.Ext(p)))
+	if n == "" {
+		return "resource"
+	}
+	return n
+}
+
+func uniqueName(n string, names map[string]struct{}) string {
+	name := n
+	for i := 2; ; i++ {
+		if _, ok := names[name]; !ok {
+			break
 		}
+		name = fmt.Sprintf("%s-%d", n, i)
+	}
+	names[name] = struct{}{}
+	return name
+}
+
+func getBasepath(p string) string {
+	u, err := url.Parse(p)
+	if err != nil {
+		return filepath.Dir(p)
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	u.Path = filepath.Dir(u.Path)
+	return u.String()
+}
+
+func read(path string) ([]byte, error) {
+	if strings.HasPrefix(path, "http") {
+		return fetch(context.Background(), defaultHTTPClient, path)
 	}
-	return fileNames, nil
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
 }
 
 func fillPackageDescriptorWithDefaultValues(descriptor map[string]interface{}) {
@@ -390,44 +1406,55 @@ func fillPackageDescriptorWithDefaultValues(descriptor map[string]interface{}) {
 	}
 }
 
-func loadPackageSchemas(d map[string]interface{}) error {
-	var err error
+// loadPackageSchemas replaces the schemas referenced by path or URL by their contents. Resource
+// schemas are resolved in the same way as resource data paths.
+func loadPackageSchemas(ctx context.Context, d map[string]interface{}, basePath string, open openFunc, client *http.Client) error {
 	if schStr, ok := d[schemaProp].(string); ok {
-		d[schemaProp], err = loadSchema(schStr)
+		sch, err := loadSchema(ctx, schStr, client)
 		if err != nil {
-			return err
+			return fmt.Errorf("error loading package schema %s: %w", schStr, err)
 		}
+		d[schemaProp] = sch
 	}
 	resources, _ := d[resourcePropName].([]interface{})
 	for _, r := range resources {
 		resMap, _ := r.(map[string]interface{})
 		if schStr, ok := resMap[schemaProp].(string); ok {
-			resMap[schemaProp], err = loadSchema(schStr)
+			name, _ := resMap[nameProp].(string)
+			sch, err := loadResourceSchema(ctx, schStr, name, basePath, open, client)
 			if err != nil {
 				return err
 			}
+			resMap[schemaProp] = sch
 		}
 	}
 	return nil
 }
 
-func buildResources(resI interface{}, basePath string, reg validator.Registry) ([]*Resource, error) {
+func buildResources(ctx context.Context, resI interface{}, basePath string, open openFunc, client *http.Client, reg validator.Registry) ([]*Resource, error) {
 	rSlice, ok := resI.([]interface{})
 	if !ok {
 		return nil, fmt.Errorf("invalid resources property. Value:\"%v\" Type:\"%v\"", resI, reflect.TypeOf(resI))
 	}
+	// All resources are checked, so all problems can be reported at once.
+	var errs MultiError
 	resources := make([]*Resource, len(rSlice))
 	for pos, rInt := range rSlice {
 		rDesc, ok := rInt.(map[string]interface{})
 		if !ok {
-			return nil, fmt.Errorf("resources must be a json object. got:%v", rInt)
+			errs = append(errs, &ResourceError{Index: pos, Err: fmt.Errorf("resources must be a json object. got:%v", rInt)})
+			continue
 		}
-		r, err := NewResource(rDesc, reg)
+		r, err := newResource(ctx, rDesc, basePath, open, client, reg)
 		if err != nil {
-			return nil, err
+			name, _ := rDesc[nameProp].(string)
+			errs = append(errs, &ResourceError{Index: pos, Name: name, Err: err})
+			continue
 		}
-		r.basePath = basePath
 		resources[pos] = r
 	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
 	return resources, nil
 }
@@ -3,6 +3,9 @@ package datapackage
 import (
 	"archive/zip"
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -11,9 +14,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/frictionlessdata/datapackage-go/validator"
+	"github.com/frictionlessdata/tableschema-go/schema"
 	"github.com/matryer/is"
 )
 
@@ -34,6 +40,17 @@ var r1Str = `{
 var r2 = map[string]interface{}{"name": "res2", "path": "bar.csv"}
 var r2Filled = map[string]interface{}{"name": "res2", "path": "bar.csv", "profile": "data-resource", "encoding": "utf-8"}
 
+// newTestPackage creates a package with the passed-in resources using the in-memory registry,
+// failing the test on errors.
+func newTestPackage(t *testing.T, basePath string, resources ...interface{}) *Package {
+	t.Helper()
+	pkg, err := New(map[string]interface{}{"resources": resources}, basePath, validator.InMemoryLoader())
+	if err != nil {
+		t.Fatalf("want:nil got:%q", err)
+	}
+	return pkg
+}
+
 func ExampleLoad_readAll() {
 	dir, _ := ioutil.TempDir("", "datapackage_exampleload")
 	defer os.RemoveAll(dir)
@@ -136,6 +153,145 @@ func TestPackage_GetResource(t *testing.T) {
 	is.True(pkg.GetResource("foooooo") == nil)
 }
 
+func TestPackage_ResourceByPath(t *testing.T) {
+	pkg, err := New(map[string]interface{}{"resources": []interface{}{
+		r1,
+		map[string]interface{}{"name": "res2", "path": []interface{}{"bar1.csv", "bar2.csv"}},
+	}}, ".", validator.InMemoryLoader())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []struct {
+		desc string
+		path string
+		want string
+	}{
+		{"ExactMatch", "foo.csv", "res1"},
+		{"MultiplePathsFirst", "bar1.csv", "res2"},
+		{"MultiplePathsSecond", "bar2.csv", "res2"},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(pkg.ResourceByPath(d.path).Name(), d.want)
+		})
+	}
+	t.Run("NoMatch", func(t *testing.T) {
+		is := is.New(t)
+		is.True(pkg.ResourceByPath("bar.csv") == nil)
+		is.True(pkg.ResourceByPath("./foo.csv") == nil)
+	})
+}
+
+func TestPackage_ContainsResource(t *testing.T) {
+	is := is.New(t)
+	pkg, err := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+	is.NoErr(err)
+	is.True(pkg.ContainsResource("res1"))
+	is.True(!pkg.ContainsResource("res2"))
+
+	is.NoErr(pkg.AddResource(r2))
+	is.True(pkg.ContainsResource("res2"))
+}
+
+func TestPackage_FindResources(t *testing.T) {
+	pkg, err := New(map[string]interface{}{"resources": []interface{}{r1, r2, map[string]interface{}{"name": "res3", "path": "baz.json"}}}, ".", validator.InMemoryLoader())
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := func(res []*Resource) []string {
+		var s []string
+		for _, r := range res {
+			s = append(s, r.Name())
+		}
+		return s
+	}
+	t.Run("Empty", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(len(pkg.FindResources(func(*Resource) bool { return false })), 0)
+	})
+	t.Run("AllMatching", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(names(pkg.FindResources(func(*Resource) bool { return true })), []string{"res1", "res2", "res3"})
+	})
+	t.Run("PartialMatching", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(names(pkg.FindResources((*Resource).Tabular)), []string{"res1", "res2"})
+	})
+}
+
+func TestPackage_Concurrency(t *testing.T) {
+	is := is.New(t)
+	pkg, err := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+	is.NoErr(err)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				pkg.GetResource("res1")
+				pkg.ContainsResource("res2")
+				pkg.ResourceNames()
+				pkg.Descriptor()
+				pkg.FindResources(func(r *Resource) bool { return pkg.ContainsResource(r.Name()) })
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		is.NoErr(pkg.AddResource(r2))
+		is.NoErr(pkg.RemoveResource("res2"))
+	}
+	close(done)
+	wg.Wait()
+	is.Equal(pkg.ResourceNames(), []string{"res1"})
+
+	t.Run("ConcurrentWriters", func(t *testing.T) {
+		is := is.New(t)
+		pkg, err := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+		is.NoErr(err)
+		const writers = 8
+		var wg sync.WaitGroup
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				name := fmt.Sprintf("res-%d", i)
+				for j := 0; j < 3; j++ {
+					if err := pkg.AddResource(map[string]interface{}{"name": name, "path": "foo.csv"}); err != nil {
+						t.Errorf("want:nil got:%q", err)
+						return
+					}
+					if err := pkg.UpdateResource(name, map[string]interface{}{"name": name, "path": "bar.csv"}); err != nil {
+						t.Errorf("want:nil got:%q", err)
+						return
+					}
+					if j < 2 {
+						if err := pkg.RemoveResource(name); err != nil {
+							t.Errorf("want:nil got:%q", err)
+							return
+						}
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+		is.Equal(len(pkg.Resources()), writers+1)
+		is.Equal(len(pkg.Descriptor()["resources"].([]interface{})), writers+1)
+		for i := 0; i < writers; i++ {
+			r := pkg.GetResource(fmt.Sprintf("res-%d", i))
+			is.True(r != nil)
+			is.Equal(r.path, []string{"bar.csv"})
+		}
+	})
+}
+
 func TestPackage_AddResource(t *testing.T) {
 	t.Run("ValidDescriptor", func(t *testing.T) {
 		is := is.New(t)
@@ -159,24 +315,144 @@ func TestPackage_AddResource(t *testing.T) {
 			t.Fatalf("want:err got:nil")
 		}
 	})
+	t.Run("RelativeSchema", func(t *testing.T) {
+		is := is.New(t)
+		dir, err := ioutil.TempDir("", "datapackage_addresource")
+		is.NoErr(err)
+		defer os.RemoveAll(dir)
+		is.NoErr(ioutil.WriteFile(filepath.Join(dir, "schema.json"), []byte(`{"fields": [{"name": "name", "type": "string"}]}`), 0666))
+		pkg, err := New(map[string]interface{}{"resources": []interface{}{r1}}, dir, validator.InMemoryLoader())
+		is.NoErr(err)
+
+		// Schema paths are resolved against the package base path.
+		is.NoErr(pkg.AddResource(map[string]interface{}{"name": "res2", "path": "bar.csv", "profile": "tabular-data-resource", "schema": "schema.json"}))
+		err = pkg.AddResource(map[string]interface{}{"name": "res3", "path": "baz.csv", "profile": "tabular-data-resource", "schema": "missing.json"})
+		is.True(errors.Is(err, os.ErrNotExist))
+		is.True(strings.Contains(err.Error(), filepath.Join(dir, "missing.json")))
+	})
 }
 
-func TestPackage_RemoveResource(t *testing.T) {
-	t.Run("Existing", func(t *testing.T) {
+func TestPackage_AddResources(t *testing.T) {
+	r3 := map[string]interface{}{"name": "res3", "path": "baz.csv"}
+	t.Run("ValidDescriptors", func(t *testing.T) {
+		is := is.New(t)
+		pkg, _ := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+		is.NoErr(pkg.AddResources([]map[string]interface{}{r2, r3}))
+
+		is.Equal(pkg.ResourceNames(), []string{"res1", "res2", "res3"})
+		resDesc := pkg.descriptor["resources"].([]interface{})
+		is.Equal(len(resDesc), 3)
+		is.Equal(resDesc[1], r2Filled)
+	})
+	t.Run("InvalidDescriptors", func(t *testing.T) {
+		is := is.New(t)
+		pkg, _ := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+		err := pkg.AddResources([]map[string]interface{}{invalidResource, r2, {"path": "baz.csv"}})
+		is.True(err != nil)
+
+		errs, ok := err.(MultiError)
+		is.True(ok)
+		is.Equal(len(errs), 2)
+		is.Equal(errs[0].(*ResourceError).Index, 0)
+		is.Equal(errs[0].(*ResourceError).Name, "res1")
+		is.Equal(errs[1].(*ResourceError).Index, 2)
+
+		// The package must not change.
+		is.Equal(pkg.ResourceNames(), []string{"res1"})
+		is.Equal(pkg.descriptor["resources"], []interface{}{r1Filled})
+	})
+}
+
+func TestPackage_UpdateResource(t *testing.T) {
+	t.Run("ValidDescriptor", func(t *testing.T) {
 		is := is.New(t)
 		pkg, _ := New(map[string]interface{}{"resources": []interface{}{r1, r2}}, ".", validator.InMemoryLoader())
-		pkg.RemoveResource("res1")
+		is.NoErr(pkg.UpdateResource("res1", map[string]interface{}{"name": "res3", "path": "baz.csv"}))
 
+		// Checking resources.
+		is.Equal(pkg.ResourceNames(), []string{"res3", "res2"})
+
+		// Checking descriptor.
 		resDesc := pkg.descriptor["resources"].([]interface{})
-		is.Equal(len(resDesc), 1)
-		is.Equal(resDesc[0], r1Filled)
-		is.Equal(len(pkg.resources), 1)
-		is.Equal(pkg.resources[0].name, "res1")
+		is.Equal(len(resDesc), 2)
+		is.Equal(resDesc[0], map[string]interface{}{"name": "res3", "path": "baz.csv", "profile": "data-resource", "encoding": "utf-8"})
+		is.Equal(resDesc[1], r2Filled)
+	})
+	t.Run("NonExisting", func(t *testing.T) {
+		is := is.New(t)
+		pkg, _ := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+		is.True(pkg.UpdateResource("invalid", r2) != nil)
+		is.Equal(pkg.ResourceNames(), []string{"res1"})
+	})
+	t.Run("InvalidResource", func(t *testing.T) {
+		is := is.New(t)
+		pkg, _ := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+		is.True(pkg.UpdateResource("res1", invalidResource) != nil)
+
+		// The package must not change.
+		is.Equal(pkg.descriptor["resources"].([]interface{})[0], r1Filled)
+		is.Equal(pkg.resources[0].path, []string{"foo.csv"})
+	})
+}
+
+func TestPackage_ReplaceResource(t *testing.T) {
+	r3 := map[string]interface{}{"name": "res3", "path": "baz.csv"}
+	t.Run("Middle", func(t *testing.T) {
+		is := is.New(t)
+		pkg, _ := New(map[string]interface{}{"resources": []interface{}{r1, r2, r3}}, ".", validator.InMemoryLoader())
+		res, err := NewResource(map[string]interface{}{"name": "res4", "path": "qux.csv"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		is.NoErr(pkg.ReplaceResource("res2", res))
+
+		is.Equal(pkg.ResourceNames(), []string{"res1", "res4", "res3"})
+		resDesc := pkg.descriptor["resources"].([]interface{})
+		is.Equal(resDesc[1], map[string]interface{}{"name": "res4", "path": "qux.csv", "profile": "data-resource", "encoding": "utf-8"})
+		is.Equal(pkg.GetResource("res4").basePath, ".")
+
+		// Changing the passed-in resource must not change the package.
+		res.descriptor["title"] = "foo"
+		_, ok := pkg.GetResource("res4").descriptor["title"]
+		is.True(!ok)
+	})
+	t.Run("NonExisting", func(t *testing.T) {
+		is := is.New(t)
+		pkg, _ := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+		res, _ := NewResource(r2, validator.MustInMemoryRegistry())
+		is.True(pkg.ReplaceResource("invalid", res) != nil)
 	})
+	t.Run("Nil", func(t *testing.T) {
+		is := is.New(t)
+		pkg, _ := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+		is.True(pkg.ReplaceResource("res1", nil) != nil)
+	})
+}
+
+func TestPackage_RemoveResource(t *testing.T) {
+	r3 := map[string]interface{}{"name": "res3", "path": "baz.csv"}
+	r3Filled := map[string]interface{}{"name": "res3", "path": "baz.csv", "profile": "data-resource", "encoding": "utf-8"}
+	data := []struct {
+		desc     string
+		name     string
+		want     []string
+		wantDesc []interface{}
+	}{
+		{"First", "res1", []string{"res2", "res3"}, []interface{}{r2Filled, r3Filled}},
+		{"Middle", "res2", []string{"res1", "res3"}, []interface{}{r1Filled, r3Filled}},
+		{"Last", "res3", []string{"res1", "res2"}, []interface{}{r1Filled, r2Filled}},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			pkg, _ := New(map[string]interface{}{"resources": []interface{}{r1, r2, r3}}, ".", validator.InMemoryLoader())
+			is.NoErr(pkg.RemoveResource(d.name))
+			is.Equal(pkg.ResourceNames(), d.want)
+			is.Equal(pkg.descriptor["resources"], d.wantDesc)
+		})
+	}
 	t.Run("NonExisting", func(t *testing.T) {
 		is := is.New(t)
 		pkg, _ := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
-		pkg.RemoveResource("invalid")
+		is.True(pkg.RemoveResource("invalid") != nil)
 
 		resDesc := pkg.descriptor["resources"].([]interface{})
 		is.Equal(len(resDesc), 1)
@@ -184,6 +460,11 @@ func TestPackage_RemoveResource(t *testing.T) {
 		is.Equal(len(pkg.resources), 1)
 		is.Equal(pkg.resources[0].name, "res1")
 	})
+	t.Run("ZeroValuePackage", func(t *testing.T) {
+		is := is.New(t)
+		var pkg Package
+		is.True(pkg.RemoveResource("res1") != nil)
+	})
 }
 
 func TestPackage_ResourceNames(t *testing.T) {
@@ -199,9 +480,14 @@ func TestPackage_Resources(t *testing.T) {
 	is.Equal(resources[0].name, "res1")
 	is.Equal(resources[1].name, "res2")
 
+	// Resources are the package ones.
+	is.True(resources[0] == pkg.GetResource("res1"))
+	is.True(resources[1] == pkg.GetResource("res2"))
+
 	// Changing the returned slice must not change the package.
-	resources = append(resources, &Resource{name: "foo"})
-	is.Equal(len(pkg.ResourceNames()), 2)
+	resources[0] = &Resource{name: "foo"}
+	resources = append(resources, &Resource{name: "bar"})
+	is.Equal(pkg.ResourceNames(), []string{"res1", "res2"})
 }
 
 func TestPackage_Descriptor(t *testing.T) {
@@ -209,6 +495,206 @@ func TestPackage_Descriptor(t *testing.T) {
 	pkg, _ := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
 	cpy := pkg.Descriptor()
 	is.Equal(pkg.descriptor, cpy)
+
+	// Changing the returned descriptor must not change the package.
+	cpy["name"] = "foo"
+	cpy["resources"].([]interface{})[0].(map[string]interface{})["name"] = "foo"
+	_, ok := pkg.descriptor["name"]
+	is.True(!ok)
+	is.Equal(pkg.descriptor["resources"].([]interface{})[0], r1Filled)
+
+	// Neither resource lookup nor validation must be affected.
+	cpy["resources"] = "foo"
+	is.True(pkg.GetResource("res1") != nil)
+	is.Equal(pkg.ResourceNames(), []string{"res1"})
+	is.NoErr(Validate(pkg.Descriptor(), validator.InMemoryLoader()))
+}
+
+func TestPackage_Clone(t *testing.T) {
+	is := is.New(t)
+	pkg, err := New(map[string]interface{}{"title": "foo", "resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+	is.NoErr(err)
+	c, err := pkg.Clone()
+	is.NoErr(err)
+	is.Equal(c.Descriptor(), pkg.Descriptor())
+	is.Equal(c.ResourceNames(), pkg.ResourceNames())
+	is.Equal(c.GetResource("res1").basePath, ".")
+
+	// Changing the clone must not change the original package.
+	c.descriptor["title"] = "bar"
+	c.descriptor["resources"].([]interface{})[0].(map[string]interface{})["path"] = "bar.csv"
+	c.GetResource("res1").descriptor["title"] = "bar"
+	is.NoErr(c.AddResource(r2))
+	is.Equal(pkg.Title(), "foo")
+	is.Equal(pkg.descriptor["resources"], []interface{}{r1Filled})
+	is.Equal(pkg.ResourceNames(), []string{"res1"})
+	_, ok := pkg.GetResource("res1").descriptor["title"]
+	is.True(!ok)
+}
+
+func TestPackage_Validate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		is := is.New(t)
+		pkg, err := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+		is.NoErr(err)
+		is.Equal(len(pkg.Validate()), 0)
+		is.True(pkg.Valid())
+	})
+	t.Run("ZeroValue", func(t *testing.T) {
+		is := is.New(t)
+		var pkg Package
+		is.True(len(pkg.Validate()) > 0)
+		is.True(!pkg.Valid())
+	})
+	t.Run("MissingResources", func(t *testing.T) {
+		is := is.New(t)
+		pkg := Package{descriptor: map[string]interface{}{"profile": "data-package"}, valRegistry: validator.MustInMemoryRegistry()}
+		errs := pkg.Validate()
+		is.True(len(errs) > 0)
+		vErr, ok := errs[0].(ValidationError)
+		is.True(ok)
+		is.Equal(vErr.Rule, RuleProfile)
+	})
+	t.Run("InvalidResources", func(t *testing.T) {
+		is := is.New(t)
+		pkg := Package{
+			descriptor: map[string]interface{}{"profile": "data-package", "resources": []interface{}{
+				map[string]interface{}{"name": "res/1", "path": "foo.csv"},
+				r2,
+				map[string]interface{}{"name": "res3", "path": []interface{}{"foo.csv", "http://example.com/bar.csv"}},
+			}},
+			valRegistry: validator.MustInMemoryRegistry(),
+		}
+		errs := pkg.Validate()
+		is.Equal(len(errs), 2)
+		is.Equal(errs[0].(ValidationError).Field, "/resources/0/name")
+		is.Equal(errs[0].(ValidationError).Rule, RuleNameFormat)
+		is.Equal(errs[1].(ValidationError).Field, "/resources/2/path/1")
+		is.Equal(errs[1].(ValidationError).Rule, RuleMixedPaths)
+		is.True(!pkg.Valid())
+	})
+}
+
+func TestPackage_ValidateContext(t *testing.T) {
+	newPackage := func(d map[string]interface{}) *Package {
+		return &Package{descriptor: d, valRegistry: validator.MustInMemoryRegistry()}
+	}
+	t.Run("Bundled", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			d     map[string]interface{}
+			valid bool
+		}{
+			{"DataPackage", map[string]interface{}{"profile": "data-package", "resources": []interface{}{r1}}, true},
+			{"DataPackageNoResources", map[string]interface{}{"profile": "data-package"}, false},
+			{"TabularDataPackage", map[string]interface{}{"profile": "tabular-data-package", "resources": []interface{}{r1}}, false},
+			{"UnknownProfile", map[string]interface{}{"profile": "foo-package", "resources": []interface{}{r1}}, false},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				errs := newPackage(d.d).ValidateContext(context.Background())
+				is.Equal(len(errs) == 0, d.valid)
+			})
+		}
+	})
+	t.Run("URL", func(t *testing.T) {
+		is := is.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"$schema": "http://json-schema.org/draft-04/schema#", "type": "object", "required": ["title"]}`)
+		}))
+		defer ts.Close()
+		is.Equal(len(newPackage(map[string]interface{}{"profile": ts.URL, "title": "foo"}).ValidateContext(context.Background())), 0)
+
+		errs := newPackage(map[string]interface{}{"profile": ts.URL}).ValidateContext(context.Background())
+		is.Equal(len(errs), 1)
+		vErr, ok := errs[0].(ValidationError)
+		is.True(ok)
+		is.Equal(vErr.Rule, RuleProfile)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		errs = newPackage(map[string]interface{}{"profile": ts.URL, "title": "foo"}).ValidateContext(ctx)
+		is.Equal(len(errs), 1)
+		is.True(errors.Is(errs[0], context.Canceled))
+	})
+	t.Run("BundledURL", func(t *testing.T) {
+		is := is.New(t)
+		transport := &countingTransport{}
+		pkg := newPackage(map[string]interface{}{"profile": "https://specs.frictionlessdata.io/schemas/data-package.json", "resources": []interface{}{r1}})
+		pkg.client = &http.Client{Transport: transport}
+		is.True(pkg.Valid())
+
+		pkg = newPackage(map[string]interface{}{"profile": "http://frictionlessdata.io/schemas/tabular-data-package.json", "resources": []interface{}{r1}})
+		pkg.client = &http.Client{Transport: transport}
+		is.True(!pkg.Valid())
+		is.Equal(len(transport.urls), 0) // Bundled profiles must not be fetched.
+	})
+}
+
+func TestPackage_ValidateForeignKeys(t *testing.T) {
+	cities := map[string]interface{}{
+		"name": "cities", "format": "csv", "data": "id,name\n1,london\n2,paris",
+		"schema": map[string]interface{}{
+			"fields":     []interface{}{map[string]interface{}{"name": "id", "type": "integer"}, map[string]interface{}{"name": "name"}},
+			"primaryKey": "id",
+		},
+	}
+	newPopulation := func(data string, fks interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name": "population", "format": "csv", "data": data,
+			"schema": map[string]interface{}{
+				"fields": []interface{}{
+					map[string]interface{}{"name": "city", "type": "integer"},
+					map[string]interface{}{"name": "parent", "type": "integer"},
+					map[string]interface{}{"name": "year", "type": "integer"},
+				},
+				"foreignKeys": fks,
+			},
+		}
+	}
+	cityFK := map[string]interface{}{"fields": "city", "reference": map[string]interface{}{"resource": "cities", "fields": "id"}}
+	selfFK := map[string]interface{}{"fields": []interface{}{"parent"}, "reference": map[string]interface{}{"resource": "", "fields": []interface{}{"city"}}}
+	t.Run("Valid", func(t *testing.T) {
+		data := []struct {
+			desc string
+			fks  interface{}
+		}{
+			{"NoForeignKeys", nil},
+			{"Reference", []interface{}{cityFK}},
+			{"SelfReference", []interface{}{selfFK}},
+			{"SingleObject", cityFK},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				pkg := newTestPackage(t, ".", cities, newPopulation("city,parent,year\n1,,2017\n2,1,2017", d.fks))
+				is.NoErr(pkg.ValidateForeignKeys())
+			})
+		}
+	})
+	t.Run("Violations", func(t *testing.T) {
+		is := is.New(t)
+		pkg := newTestPackage(t, ".", cities, newPopulation("city,parent,year\n1,,2017\n3,1,2017\n2,4,2017", []interface{}{cityFK, selfFK}))
+		err := pkg.ValidateForeignKeys()
+		errs, ok := err.(MultiError)
+		is.True(ok)
+		is.Equal(len(errs), 2)
+		is.Equal(*errs[0].(*ForeignKeyError), ForeignKeyError{Resource: "population", Row: 1, Fields: []string{"city"}, Values: []interface{}{int64(3)}, Reference: "cities"})
+		is.Equal(*errs[1].(*ForeignKeyError), ForeignKeyError{Resource: "population", Row: 2, Fields: []string{"parent"}, Values: []interface{}{int64(4)}, Reference: "population"})
+	})
+	t.Run("MissingReferencedResource", func(t *testing.T) {
+		is := is.New(t)
+		pkg := newTestPackage(t, ".", newPopulation("city,parent,year\n1,,2017", []interface{}{cityFK}))
+		is.True(pkg.ValidateForeignKeys() != nil)
+	})
+	t.Run("ReadError", func(t *testing.T) {
+		is := is.New(t)
+		pkg := newTestPackage(t, ".", cities, newPopulation("city,parent,year\nfoo,,2017", []interface{}{cityFK}))
+		var castErr *CastError
+		is.True(errors.As(pkg.ValidateForeignKeys(), &castErr))
+		is.Equal(castErr.Field, "city")
+	})
 }
 
 func TestPackage_Update(t *testing.T) {
@@ -259,6 +745,152 @@ func TestFromDescriptor(t *testing.T) {
 		is.Equal(len(resources), 1)
 		is.Equal(resources[0], r1Filled)
 	})
+	t.Run("CallerMutation", func(t *testing.T) {
+		is := is.New(t)
+		res := map[string]interface{}{"name": "res1", "path": "foo.csv"}
+		descriptor := map[string]interface{}{"resources": []interface{}{res}}
+		pkg, err := New(descriptor, ".", validator.InMemoryLoader())
+		is.NoErr(err)
+
+		// Changing the passed-in descriptor must not change the package.
+		res["name"] = "res/1"
+		descriptor["resources"] = 10
+		added := map[string]interface{}{"name": "res2", "path": "bar.csv"}
+		is.NoErr(pkg.AddResource(added))
+		added["path"] = 10
+		is.Equal(pkg.descriptor["resources"], []interface{}{r1Filled, r2Filled})
+		is.Equal(pkg.ResourceNames(), []string{"res1", "res2"})
+		is.NoErr(Validate(pkg.Descriptor(), validator.InMemoryLoader()))
+	})
+	t.Run("AllResourceErrors", func(t *testing.T) {
+		is := is.New(t)
+		_, err := New(map[string]interface{}{"resources": []interface{}{
+			map[string]interface{}{"name": "res1", "path": "foo.csv", "profile": "tabular-data-resource"},
+			r2,
+			map[string]interface{}{"name": "res3", "path": "bar.csv", "profile": "tabular-data-resource"},
+			map[string]interface{}{"name": "res/4", "path": "baz.csv"},
+		}}, ".", validator.InMemoryLoader())
+		multi, ok := err.(MultiError)
+		is.True(ok)
+		is.Equal(len(multi.Unwrap()), 3)
+		var got []string
+		for _, e := range multi {
+			rErr, ok := e.(*ResourceError)
+			is.True(ok)
+			got = append(got, fmt.Sprintf("%d:%s", rErr.Index, rErr.Name))
+		}
+		is.Equal(got, []string{"0:res1", "2:res3", "3:res/4"})
+		is.True(strings.HasPrefix(err.Error(), "3 error(s) found:\nresource 0 (res1): "))
+	})
+	t.Run("CachedProfile", func(t *testing.T) {
+		is := is.New(t)
+		var hits int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&hits, 1)
+			fmt.Fprint(w, `{"$schema": "http://json-schema.org/draft-04/schema#", "type": "object", "required": ["resources"]}`)
+		}))
+		defer ts.Close()
+		loader := validator.WithProfileCache(validator.InMemoryLoader())
+		for i := 0; i < 3; i++ {
+			_, err := New(map[string]interface{}{"profile": ts.URL, "resources": []interface{}{r1}}, ".", loader)
+			is.NoErr(err)
+		}
+		is.Equal(atomic.LoadInt32(&hits), int32(1))
+	})
+}
+
+func TestSlugifyResourceNames(t *testing.T) {
+	is := is.New(t)
+	d := map[string]interface{}{"resources": []interface{}{
+		map[string]interface{}{"name": "Station Data", "path": "foo.csv"},
+		r2,
+	}}
+	_, err := New(d, ".", validator.InMemoryLoader())
+	is.True(err != nil)
+
+	slugified, err := SlugifyResourceNames(d)
+	is.NoErr(err)
+	pkg, err := New(slugified, ".", validator.InMemoryLoader())
+	is.NoErr(err)
+	is.Equal(pkg.ResourceNames(), []string{"station-data", "res2"})
+
+	// The passed-in descriptor must not change.
+	is.Equal(d["resources"].([]interface{})[0].(map[string]interface{})["name"], "Station Data")
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		is := is.New(t)
+		is.NoErr(Validate(map[string]interface{}{"resources": []interface{}{r1}}, validator.InMemoryLoader()))
+	})
+	t.Run("TabularProfile", func(t *testing.T) {
+		is := is.New(t)
+		err := Validate(map[string]interface{}{"profile": "tabular-data-package", "resources": []interface{}{r1}}, validator.InMemoryLoader())
+		errs, ok := err.(MultiError)
+		is.True(ok)
+		is.Equal(errs[0].(ValidationError).Rule, RuleProfile)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		is := is.New(t)
+		err := Validate(map[string]interface{}{
+			"licenses": "ODC-BY-1.0",
+			"resources": []interface{}{
+				r1,
+				map[string]interface{}{"name": "res2", "path": "bar.csv", "profile": "tabular-data-resource"},
+			},
+		}, validator.InMemoryLoader())
+		errs, ok := err.(MultiError)
+		is.True(ok)
+		is.True(len(errs) > 1)
+		is.Equal(errs[0].(ValidationError).Field, "/licenses")
+		for _, e := range errs[1:] {
+			vErr, ok := e.(ValidationError)
+			is.True(ok)
+			is.True(strings.HasPrefix(vErr.Field, "/resources/1"))
+		}
+	})
+	t.Run("ProfileNotAString", func(t *testing.T) {
+		is := is.New(t)
+		err := Validate(map[string]interface{}{"profile": 1, "resources": []interface{}{r1}}, validator.InMemoryLoader())
+		errs, ok := err.(MultiError)
+		is.True(ok)
+		is.Equal(errs[0], ValidationError{Field: "/profile", Rule: RuleProfile, Message: "profile property MUST be a string"})
+	})
+	t.Run("UnknownProfile", func(t *testing.T) {
+		is := is.New(t)
+		is.True(Validate(map[string]interface{}{"profile": "foo", "resources": []interface{}{r1}}, validator.InMemoryLoader()) != nil)
+	})
+}
+
+func TestNewValidationReport(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		is := is.New(t)
+		report, err := NewValidationReport(map[string]interface{}{"resources": []interface{}{r1}}, validator.InMemoryLoader())
+		is.NoErr(err)
+		is.True(report.Valid())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		is := is.New(t)
+		report, err := NewValidationReport(map[string]interface{}{
+			"licenses": "ODC-BY-1.0",
+			"resources": []interface{}{
+				r1,
+				map[string]interface{}{"name": "res2", "path": "bar.csv", "profile": "tabular-data-resource"},
+			},
+		}, validator.InMemoryLoader())
+		is.NoErr(err)
+		is.True(!report.Valid())
+		is.True(len(report.Errors) > 1)
+		is.Equal(report.Errors[0].Field, "/licenses")
+		for _, e := range report.Errors[1:] {
+			is.True(strings.HasPrefix(e.Field, "/resources/1"))
+		}
+	})
+	t.Run("UnknownProfile", func(t *testing.T) {
+		is := is.New(t)
+		_, err := NewValidationReport(map[string]interface{}{"profile": "foo", "resources": []interface{}{r1}}, validator.InMemoryLoader())
+		is.True(err != nil)
+	})
 }
 
 func TestPackage_SaveDescriptor(t *testing.T) {
@@ -282,6 +914,257 @@ func TestPackage_SaveDescriptor(t *testing.T) {
 	})
 }
 
+func TestPackage_SaveToDirectory(t *testing.T) {
+	is := is.New(t)
+	srcDir, err := ioutil.TempDir("", "datapackage_savetodir_src")
+	is.NoErr(err)
+	defer os.RemoveAll(srcDir)
+	is.NoErr(os.Mkdir(filepath.Join(srcDir, "data"), os.ModePerm))
+	is.NoErr(ioutil.WriteFile(filepath.Join(srcDir, "data", "data.csv"), []byte("foo\nbar"), 0666))
+
+	dir, err := ioutil.TempDir("", "datapackage_savetodir")
+	is.NoErr(err)
+	defer os.RemoveAll(dir)
+	dir = filepath.Join(dir, "pkg") // Must be created.
+
+	pkg, err := New(map[string]interface{}{
+		"resources": []interface{}{
+			map[string]interface{}{"name": "local", "path": "data/data.csv"},
+			map[string]interface{}{"name": "remote", "path": "http://foo.com/bar.csv"},
+			map[string]interface{}{"name": "inline", "data": []interface{}{map[string]interface{}{"a": "b"}}},
+		},
+	}, srcDir, validator.InMemoryLoader())
+	is.NoErr(err)
+	is.NoErr(pkg.SaveToDirectory(dir))
+
+	// Checking the resulting file tree.
+	contents, err := ioutil.ReadFile(filepath.Join(dir, "data", "data.csv"))
+	is.NoErr(err)
+	is.Equal(string(contents), "foo\nbar")
+	contents, err = ioutil.ReadFile(filepath.Join(dir, "inline.json"))
+	is.NoErr(err)
+	is.Equal(string(contents), "[\n  {\n    \"a\": \"b\"\n  }\n]")
+	_, err = os.Stat(filepath.Join(dir, "bar.csv"))
+	is.True(os.IsNotExist(err))
+
+	// Checking the saved descriptor.
+	saved, err := Load(filepath.Join(dir, "datapackage.json"), validator.InMemoryLoader())
+	is.NoErr(err)
+	is.Equal(saved.GetResource("local").path, []string{"data/data.csv"})
+	is.Equal(saved.GetResource("remote").path, []string{"http://foo.com/bar.csv"})
+	is.Equal(saved.GetResource("inline").path, []string{"inline.json"})
+	is.True(saved.GetResource("inline").Descriptor()["data"] == nil)
+
+	// The package itself must not change.
+	is.True(pkg.GetResource("inline").data != nil)
+}
+
+func TestPackage_SaveToDirectoryCollisions(t *testing.T) {
+	is := is.New(t)
+	srcDir, err := ioutil.TempDir("", "datapackage_savetodir_src")
+	is.NoErr(err)
+	defer os.RemoveAll(srcDir)
+	is.NoErr(ioutil.WriteFile(filepath.Join(srcDir, "inline.json"), []byte(`{"foo": "bar"}`), 0666))
+
+	dir, err := ioutil.TempDir("", "datapackage_savetodir")
+	is.NoErr(err)
+	defer os.RemoveAll(dir)
+
+	pkg, err := New(map[string]interface{}{
+		"resources": []interface{}{
+			map[string]interface{}{"name": "datapackage", "data": []interface{}{"a"}},
+			map[string]interface{}{"name": "inline", "data": []interface{}{"b"}},
+			map[string]interface{}{"name": "local", "path": "inline.json"},
+		},
+	}, srcDir, validator.InMemoryLoader())
+	is.NoErr(err)
+	is.NoErr(pkg.SaveToDirectory(dir))
+
+	// Inlined data must not overwrite the descriptor nor the files of other resources.
+	saved, err := Load(filepath.Join(dir, "datapackage.json"), validator.InMemoryLoader())
+	is.NoErr(err)
+	is.Equal(saved.GetResource("datapackage").path, []string{"datapackage_1.json"})
+	is.Equal(saved.GetResource("inline").path, []string{"inline_1.json"})
+	contents, err := saved.GetResource("local").Bytes("")
+	is.NoErr(err)
+	is.Equal(string(contents), `{"foo": "bar"}`)
+	contents, err = saved.GetResource("inline").Bytes("")
+	is.NoErr(err)
+	is.Equal(string(contents), "[\n  \"b\"\n]")
+}
+
+func TestPackage_Metadata(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		is := is.New(t)
+		pkg, err := New(map[string]interface{}{
+			"name":        "world",
+			"title":       "World",
+			"description": "World population",
+			"version":     "1.0.0",
+			"keywords":    []interface{}{"population", "cities"},
+			"licenses": []interface{}{
+				map[string]interface{}{"name": "ODC-PDDL-1.0", "path": "http://opendatacommons.org/licenses/pddl/", "title": "Open Data Commons Public Domain Dedication and License v1.0"},
+			},
+			"resources": []interface{}{r1},
+		}, ".", validator.InMemoryLoader())
+		is.NoErr(err)
+		is.Equal(pkg.Name(), "world")
+		is.Equal(pkg.Title(), "World")
+		is.Equal(pkg.Description(), "World population")
+		is.Equal(pkg.Version(), "1.0.0")
+		is.Equal(pkg.Keywords(), []string{"population", "cities"})
+		is.Equal(pkg.Licenses(), []License{{Name: "ODC-PDDL-1.0", Path: "http://opendatacommons.org/licenses/pddl/", Title: "Open Data Commons Public Domain Dedication and License v1.0"}})
+	})
+	t.Run("Absent", func(t *testing.T) {
+		is := is.New(t)
+		pkg, err := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+		is.NoErr(err)
+		is.Equal(pkg.Name(), "")
+		is.Equal(pkg.Title(), "")
+		is.Equal(pkg.Description(), "")
+		is.Equal(pkg.Version(), "")
+		is.Equal(len(pkg.Keywords()), 0)
+		is.Equal(len(pkg.Licenses()), 0)
+	})
+	t.Run("UnexpectedTypes", func(t *testing.T) {
+		is := is.New(t)
+		pkg := Package{descriptor: map[string]interface{}{
+			"name":     1,
+			"version":  []interface{}{},
+			"keywords": []interface{}{"foo", 1},
+			"licenses": []interface{}{"foo", map[string]interface{}{"name": 1, "path": "bar"}},
+		}}
+		is.Equal(pkg.Name(), "")
+		is.Equal(pkg.Version(), "")
+		is.Equal(pkg.Keywords(), []string{"foo"})
+		is.Equal(pkg.Licenses(), []License{{Path: "bar"}})
+	})
+	t.Run("Setters", func(t *testing.T) {
+		is := is.New(t)
+		pkg, err := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+		is.NoErr(err)
+		is.NoErr(pkg.SetName("world"))
+		pkg.SetTitle("World")
+		pkg.SetDescription("World population")
+		pkg.SetVersion("1.0.0")
+		licenses := []License{{Name: "ODC-PDDL-1.0", Title: "Open Data Commons Public Domain Dedication and License v1.0"}, {Path: "LICENSE.txt"}}
+		is.NoErr(pkg.SetLicenses(licenses))
+		contributors := []Contributor{{Title: "Joe Bloggs", Email: "joe@bloggs.com", Role: "author", Organization: "Bloggs Inc."}}
+		is.NoErr(pkg.SetContributors(contributors))
+		sources := []Source{{Title: "World Bank", Path: "http://data.worldbank.org/indicator/NY.GDP.MKTP.CD"}}
+		is.NoErr(pkg.SetSources(sources))
+
+		d := pkg.Descriptor()
+		is.Equal(d["name"], "world")
+		is.Equal(d["licenses"], []interface{}{
+			map[string]interface{}{"name": "ODC-PDDL-1.0", "title": "Open Data Commons Public Domain Dedication and License v1.0"},
+			map[string]interface{}{"path": "LICENSE.txt"},
+		})
+
+		// Marshalling round-trip.
+		buf, err := json.Marshal(pkg)
+		is.NoErr(err)
+		newPkg, err := FromReader(bytes.NewReader(buf), ".", validator.InMemoryLoader())
+		is.NoErr(err)
+		is.Equal(newPkg.Name(), "world")
+		is.Equal(newPkg.Title(), "World")
+		is.Equal(newPkg.Description(), "World population")
+		is.Equal(newPkg.Version(), "1.0.0")
+		is.Equal(newPkg.Licenses(), licenses)
+		is.Equal(newPkg.Contributors(), contributors)
+		is.Equal(newPkg.Sources(), sources)
+
+		// Empty values remove the properties.
+		is.NoErr(pkg.SetName(""))
+		pkg.SetTitle("")
+		is.NoErr(pkg.SetLicenses(nil))
+		is.NoErr(pkg.SetContributors(nil))
+		is.NoErr(pkg.SetSources([]Source{}))
+		d = pkg.Descriptor()
+		for _, prop := range []string{"name", "title", "licenses", "contributors", "sources"} {
+			_, ok := d[prop]
+			is.True(!ok)
+		}
+	})
+	t.Run("InvalidSetters", func(t *testing.T) {
+		is := is.New(t)
+		pkg, err := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+		is.NoErr(err)
+		is.True(pkg.SetName("My World") != nil)
+		is.True(pkg.SetLicenses([]License{{Name: "MIT"}, {Title: "No name nor path"}}) != nil)
+		is.True(pkg.SetContributors([]Contributor{{Email: "joe@bloggs.com"}}) != nil)
+		is.True(pkg.SetSources([]Source{{Path: "http://example.com"}}) != nil)
+		// The package is left unchanged.
+		is.Equal(pkg.Descriptor(), map[string]interface{}{"profile": "data-package", "resources": []interface{}{r1Filled}})
+	})
+	t.Run("AbsentLists", func(t *testing.T) {
+		is := is.New(t)
+		pkg := Package{descriptor: map[string]interface{}{"contributors": "foo", "sources": []interface{}{1}}}
+		is.Equal(len(pkg.Contributors()), 0)
+		is.Equal(len(pkg.Sources()), 0)
+	})
+}
+
+func TestPackage_ToJSON(t *testing.T) {
+	t.Run("Indent", func(t *testing.T) {
+		is := is.New(t)
+		pkg, _ := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+		var buf bytes.Buffer
+		is.NoErr(pkg.ToJSON(&buf, true))
+		is.Equal(buf.String(), r1Str+"\n")
+
+		// The output must be loadable.
+		newPkg, err := FromReader(&buf, ".", validator.InMemoryLoader())
+		is.NoErr(err)
+		is.Equal(newPkg.Descriptor(), pkg.Descriptor())
+	})
+	t.Run("NoIndent", func(t *testing.T) {
+		is := is.New(t)
+		pkg, _ := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+		var buf bytes.Buffer
+		is.NoErr(pkg.ToJSON(&buf, false))
+		is.True(!strings.Contains(buf.String(), "  "))
+
+		newPkg, err := FromReader(&buf, ".", validator.InMemoryLoader())
+		is.NoErr(err)
+		is.Equal(newPkg.Descriptor(), pkg.Descriptor())
+	})
+}
+
+func TestPackage_Infer(t *testing.T) {
+	is := is.New(t)
+	dir, err := ioutil.TempDir("", "datapackage_infer")
+	is.NoErr(err)
+	defer os.RemoveAll(dir)
+	is.NoErr(ioutil.WriteFile(filepath.Join(dir, "cities.csv"), []byte("city,population\nlondon,8780000"), 0666))
+
+	sch := map[string]interface{}{"fields": []interface{}{map[string]interface{}{"name": "population", "type": "string"}}}
+	pkg, err := New(map[string]interface{}{"resources": []interface{}{
+		map[string]interface{}{"name": "cities", "path": "cities.csv"},
+		map[string]interface{}{"name": "explicit", "path": "cities.csv", "schema": sch},
+	}}, dir, validator.InMemoryLoader())
+	is.NoErr(err)
+	before := pkg.GetResource("cities")
+	is.NoErr(pkg.Infer())
+
+	resources := pkg.Descriptor()["resources"].([]interface{})
+	cities := resources[0].(map[string]interface{})
+	is.Equal(cities["format"], "csv")
+	fields := cities["schema"].(map[string]interface{})["fields"].([]interface{})
+	is.Equal(len(fields), 2)
+	is.Equal(fields[1].(map[string]interface{})["type"], "integer")
+	is.Equal(resources[1].(map[string]interface{})["schema"], sch)
+
+	// Resources must reflect the updated descriptor.
+	s, err := pkg.GetResource("cities").GetSchema()
+	is.NoErr(err)
+	is.Equal(s.Fields[0].Name, "city")
+
+	// Resources obtained before the inference are left untouched.
+	_, ok := before.Descriptor()["schema"]
+	is.True(!ok)
+}
+
 func TestPackage_Zip(t *testing.T) {
 	t.Run("Valid", func(t *testing.T) {
 		is := is.New(t)
@@ -412,6 +1295,165 @@ func TestPackage_Zip(t *testing.T) {
 		is.Equal(buf.String(), string(resContents))
 	})
 }
+func TestPackage_WriteZip(t *testing.T) {
+	is := is.New(t)
+	dir, err := ioutil.TempDir("", "datapackage_writezip")
+	is.NoErr(err)
+	defer os.RemoveAll(dir)
+	is.NoErr(os.Mkdir(filepath.Join(dir, "data"), os.ModePerm))
+	is.NoErr(ioutil.WriteFile(filepath.Join(dir, "data", "data.csv"), []byte("foo\nbar"), 0666))
+
+	pkg, err := New(map[string]interface{}{
+		"resources": []interface{}{
+			map[string]interface{}{"name": "local", "path": "./data/data.csv", "format": "csv"},
+			map[string]interface{}{"name": "remote", "path": "http://foo.com/bar.csv"},
+		},
+	}, dir, validator.InMemoryLoader())
+	is.NoErr(err)
+	var buf bytes.Buffer
+	is.NoErr(pkg.WriteZip(&buf))
+
+	// Checking zip contents.
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	is.NoErr(err)
+	is.Equal(len(reader.File), 2)
+	is.Equal(reader.File[0].Name, "datapackage.json")
+	is.Equal(reader.File[1].Name, "data/data.csv")
+
+	// Loading the package back.
+	newPkg, err := FromZipReader(reader, validator.InMemoryLoader())
+	is.NoErr(err)
+	is.Equal(newPkg.Descriptor(), pkg.Descriptor())
+	contents, err := newPkg.GetResource("local").ReadAll()
+	is.NoErr(err)
+	is.Equal(contents, [][]string{{"foo"}, {"bar"}})
+	is.Equal(newPkg.GetResource("remote").path, []string{"http://foo.com/bar.csv"})
+}
+
+func TestFromZipReader(t *testing.T) {
+	t.Run("NoDescriptor", func(t *testing.T) {
+		is := is.New(t)
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		_, err := w.Create("otherpackage.json")
+		is.NoErr(err)
+		is.NoErr(w.Close())
+		reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		is.NoErr(err)
+		if _, err := FromZipReader(reader, validator.InMemoryLoader()); err == nil {
+			t.Fatalf("want:err got:nil")
+		}
+	})
+	t.Run("ReadsFromEntries", func(t *testing.T) {
+		is := is.New(t)
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		files := map[string]string{
+			"datapackage.json": `{"resources":[{"name":"res1","path":"data/foo.csv","format":"csv"}]}`,
+			"data/foo.csv":     "name\nfoo",
+		}
+		for name, contents := range files {
+			f, err := w.Create(name)
+			is.NoErr(err)
+			_, err = f.Write([]byte(contents))
+			is.NoErr(err)
+		}
+		is.NoErr(w.Close())
+		b := buf.Bytes()
+		reader, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		is.NoErr(err)
+
+		pkg, err := FromZipReader(reader, validator.InMemoryLoader())
+		is.NoErr(err)
+		contents, err := pkg.GetResource("res1").ReadAll()
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"name"}, {"foo"}})
+
+		rc, err := pkg.GetResource("res1").RawRead()
+		is.NoErr(err)
+		defer rc.Close()
+		raw, err := ioutil.ReadAll(rc)
+		is.NoErr(err)
+		is.Equal(string(raw), "name\nfoo")
+
+		// Packages created or updated from the zip-backed package are still backed by the archive.
+		is.NoErr(pkg.AddResource(map[string]interface{}{"name": "res2", "path": "data/foo.csv", "format": "csv"}))
+		contents, err = pkg.GetResource("res2").ReadAll()
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"name"}, {"foo"}})
+
+		var out bytes.Buffer
+		is.NoErr(pkg.WriteZip(&out))
+		outReader, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+		is.NoErr(err)
+		newPkg, err := FromZipReader(outReader, validator.InMemoryLoader())
+		is.NoErr(err)
+		contents, err = newPkg.GetResource("res2").ReadAll()
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"name"}, {"foo"}})
+	})
+	t.Run("MissingEntry", func(t *testing.T) {
+		is := is.New(t)
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		f, err := w.Create("datapackage.json")
+		is.NoErr(err)
+		_, err = f.Write([]byte(`{"resources":[{"name":"res1","path":"foo.csv","format":"csv"}]}`))
+		is.NoErr(err)
+		is.NoErr(w.Close())
+		reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		is.NoErr(err)
+		pkg, err := FromZipReader(reader, validator.InMemoryLoader())
+		is.NoErr(err)
+		_, err = pkg.GetResource("res1").ReadAll()
+		is.True(err != nil)
+	})
+}
+
+func TestPackage_JSON(t *testing.T) {
+	type payload struct {
+		ID      string   `json:"id"`
+		Package *Package `json:"package"`
+	}
+	t.Run("RoundTrip", func(t *testing.T) {
+		is := is.New(t)
+		pkg, err := New(map[string]interface{}{"homepage": "http://example.com", "resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+		is.NoErr(err)
+		b, err := json.Marshal(payload{ID: "foo", Package: pkg})
+		is.NoErr(err)
+
+		var got payload
+		is.NoErr(json.Unmarshal(b, &got))
+		is.Equal(got.ID, "foo")
+		is.Equal(got.Package.Descriptor(), pkg.Descriptor())
+		is.Equal(got.Package.Descriptor()["homepage"], "http://example.com")
+		is.Equal(got.Package.ResourceNames(), []string{"res1"})
+	})
+	t.Run("InvalidDescriptor", func(t *testing.T) {
+		data := []struct {
+			desc string
+			json string
+		}{
+			{"InvalidResource", `{"package": {"resources": [{"name": "res1"}]}}`},
+			{"NoResources", `{"package": {"name": "foo"}}`},
+			{"InvalidJSON", `{"package": {"resources": }}`},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				var got payload
+				is.True(json.Unmarshal([]byte(d.json), &got) != nil)
+			})
+		}
+	})
+	t.Run("KeepsBasePath", func(t *testing.T) {
+		is := is.New(t)
+		pkg := Package{basePath: "data"}
+		is.NoErr(json.Unmarshal([]byte(`{"resources": [{"name": "res1", "path": "foo.csv"}]}`), &pkg))
+		is.Equal(pkg.GetResource("res1").basePath, "data")
+	})
+}
+
 func TestFromReader(t *testing.T) {
 	t.Run("ValidJSON", func(t *testing.T) {
 		is := is.New(t)
@@ -423,6 +1465,123 @@ func TestFromReader(t *testing.T) {
 		_, err := FromReader(strings.NewReader(`{resources}`), ".", validator.InMemoryLoader())
 		is.True(err != nil)
 	})
+	t.Run("MultipartPath", func(t *testing.T) {
+		is := is.New(t)
+		pkg, err := FromReader(strings.NewReader(`{"resources":[{"name":"res", "path":["foo.csv", "bar.csv"]}]}`), ".", validator.InMemoryLoader())
+		is.NoErr(err)
+		is.Equal(pkg.GetResource("res").path, []string{"foo.csv", "bar.csv"})
+
+		// Round-tripping the descriptor.
+		var buf bytes.Buffer
+		is.NoErr(pkg.ToJSON(&buf, false))
+		newPkg, err := FromReader(&buf, ".", validator.InMemoryLoader())
+		is.NoErr(err)
+		is.Equal(newPkg.GetResource("res").path, []string{"foo.csv", "bar.csv"})
+		is.Equal(newPkg.Descriptor(), pkg.Descriptor())
+	})
+	t.Run("InlineTabularRows", func(t *testing.T) {
+		is := is.New(t)
+		in := `{"resources":[{
+			"name": "res",
+			"profile": "tabular-data-resource",
+			"schema": {"fields": [{"name": "a", "type": "integer"}]},
+			"data": [{"a": 1}, {"a": 2}]
+		}]}`
+		pkg, err := FromReader(strings.NewReader(in), ".", validator.InMemoryLoader())
+		is.NoErr(err)
+		is.Equal(pkg.GetResource("res").data, []interface{}{map[string]interface{}{"a": 1.0}, map[string]interface{}{"a": 2.0}})
+
+		// Round-tripping the descriptor.
+		var buf bytes.Buffer
+		is.NoErr(pkg.ToJSON(&buf, false))
+		newPkg, err := FromReader(&buf, ".", validator.InMemoryLoader())
+		is.NoErr(err)
+		is.Equal(newPkg.Descriptor(), pkg.Descriptor())
+	})
+	t.Run("InlineTabularInvalidRow", func(t *testing.T) {
+		is := is.New(t)
+		in := `{"resources":[{
+			"name": "res",
+			"profile": "tabular-data-resource",
+			"schema": {"fields": [{"name": "a", "type": "integer"}]},
+			"data": [{"a": 1}, 2]
+		}]}`
+		_, err := FromReader(strings.NewReader(in), ".", validator.InMemoryLoader())
+		is.True(err != nil)
+	})
+	t.Run("MultipartPathNotString", func(t *testing.T) {
+		is := is.New(t)
+		_, err := FromReader(strings.NewReader(`{"resources":[{"name":"res", "path":["foo.csv", 1]}]}`), ".", validator.InMemoryLoader())
+		is.True(err != nil)
+	})
+}
+
+func TestInferFromDir(t *testing.T) {
+	setup := func(is *is.I) string {
+		dir, err := ioutil.TempDir("", "datapackage_inferfromdir")
+		is.NoErr(err)
+		is.NoErr(os.Mkdir(filepath.Join(dir, "sub"), 0755))
+		files := map[string]string{
+			"Cities_2017.csv":  "city,population\nlondon,8780000",
+			"sub/cities.csv":   "city\nrome",
+			"meta.json":        `{"foo": "bar"}`,
+			"blob.bin":         "\x00\x01\x02",
+			".hidden.csv":      "a\n1",
+			"datapackage.json": "{}",
+		}
+		for f, c := range files {
+			is.NoErr(ioutil.WriteFile(filepath.Join(dir, f), []byte(c), 0666))
+		}
+		return dir
+	}
+	t.Run("Patterns", func(t *testing.T) {
+		is := is.New(t)
+		dir := setup(is)
+		defer os.RemoveAll(dir)
+		pkg, err := InferFromDir(dir, "*.csv", "*.json")
+		is.NoErr(err)
+		is.Equal(pkg.ResourceNames(), []string{"cities_2017", "meta", "sub-cities"})
+
+		cities := pkg.GetResource("cities_2017").Descriptor()
+		is.Equal(cities["path"], "Cities_2017.csv")
+		is.Equal(cities["format"], "csv")
+		s, err := pkg.GetResource("cities_2017").GetSchema()
+		is.NoErr(err)
+		is.Equal(s.Fields[1].Type, schema.IntegerType)
+		is.Equal(pkg.GetResource("meta").Descriptor()["format"], "json")
+		is.Equal(pkg.GetResource("sub-cities").Descriptor()["path"], "sub/cities.csv")
+
+		// The result must be savable and loadable.
+		is.NoErr(pkg.SaveDescriptor(filepath.Join(dir, "datapackage.json")))
+		loaded, err := Load(filepath.Join(dir, "datapackage.json"), validator.InMemoryLoader())
+		is.NoErr(err)
+		is.Equal(loaded.Descriptor(), pkg.Descriptor())
+		contents, err := loaded.GetResource("sub-cities").ReadAll()
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"city"}, {"rome"}})
+	})
+	t.Run("NoPatterns", func(t *testing.T) {
+		is := is.New(t)
+		dir := setup(is)
+		defer os.RemoveAll(dir)
+		pkg, err := InferFromDir(dir)
+		is.NoErr(err)
+		is.Equal(pkg.ResourceNames(), []string{"cities_2017", "blob", "meta", "sub-cities"})
+	})
+	t.Run("NoMatches", func(t *testing.T) {
+		is := is.New(t)
+		dir := setup(is)
+		defer os.RemoveAll(dir)
+		_, err := InferFromDir(dir, "*.xml")
+		is.True(err != nil)
+	})
+	t.Run("InvalidPattern", func(t *testing.T) {
+		is := is.New(t)
+		dir := setup(is)
+		defer os.RemoveAll(dir)
+		_, err := InferFromDir(dir, "[")
+		is.True(err != nil)
+	})
 }
 
 func TestLoad(t *testing.T) {
@@ -444,6 +1603,17 @@ func TestLoad(t *testing.T) {
 		is.Equal(res.name, "res1")
 		is.Equal(res.path, []string{"foo.csv"})
 	})
+	t.Run("MissingSchema", func(t *testing.T) {
+		is := is.New(t)
+		fName := filepath.Join(dir, "pkg.json")
+		is.NoErr(ioutil.WriteFile(fName, []byte(`{"resources": [{"name": "res1", "path": "foo.csv", "profile": "tabular-data-resource", "schema": "missing.json"}]}`), 0666))
+		defer os.Remove(fName)
+
+		_, err := Load(fName, validator.InMemoryLoader())
+		is.True(errors.Is(err, os.ErrNotExist))
+		is.True(strings.Contains(err.Error(), filepath.Join(dir, "missing.json"))) // The resolved path.
+		is.True(strings.Contains(err.Error(), "res1"))
+	})
 	t.Run("LocalZip", func(t *testing.T) {
 		is := is.New(t)
 		// Creating a zip file.
@@ -597,4 +1767,40 @@ func TestLoadPackageSchemas(t *testing.T) {
 	is.NoErr(err)
 	is.Equal(pkg.Descriptor()["schema"], schMap)
 	is.Equal(pkg.GetResource("res1").Descriptor()["schema"], schMap)
+
+	t.Run("RelativePath", func(t *testing.T) {
+		is := is.New(t)
+		dir, err := ioutil.TempDir("", "datapackage_schemas")
+		is.NoErr(err)
+		defer os.RemoveAll(dir)
+		is.NoErr(ioutil.WriteFile(filepath.Join(dir, "schema.json"), []byte(schStr), 0666))
+		in := `{"resources": [{"name": "res1", "path": "data.csv", "profile": "tabular-data-resource", "schema": "schema.json"}]}`
+		pkg, err := FromString(in, dir, validator.InMemoryLoader())
+		is.NoErr(err)
+		is.Equal(pkg.GetResource("res1").Descriptor()["schema"], schMap)
+	})
+}
+
+func TestPackage_DialectReference(t *testing.T) {
+	is := is.New(t)
+	dir, err := ioutil.TempDir("", "datapackage_dialect")
+	is.NoErr(err)
+	defer os.RemoveAll(dir)
+	is.NoErr(os.Mkdir(filepath.Join(dir, "dialects"), os.ModePerm))
+	is.NoErr(ioutil.WriteFile(filepath.Join(dir, "dialects", "semicolon.json"), []byte(`{"delimiter": ";", "doubleQuote": true}`), 0666))
+	is.NoErr(ioutil.WriteFile(filepath.Join(dir, "data.csv"), []byte("name;age\nfoo;42"), 0666))
+	in := `{"profile": "tabular-data-package", "resources": [{"name": "res1", "path": "data.csv", "profile": "tabular-data-resource", "dialect": "dialects/semicolon.json", "schema": {"fields": [{"name": "name", "type": "string"}, {"name": "age", "type": "integer"}]}}]}`
+	pkg, err := FromString(in, dir, validator.InMemoryLoader())
+	is.NoErr(err)
+	is.Equal(len(pkg.Validate()), 0)
+	contents, err := pkg.GetResource("res1").ReadAll()
+	is.NoErr(err)
+	is.Equal(contents, [][]string{{"foo", "42"}})
+
+	// The dialect file is saved along with the data.
+	out := filepath.Join(dir, "out")
+	is.NoErr(pkg.SaveToDirectory(out))
+	buf, err := ioutil.ReadFile(filepath.Join(out, "dialects", "semicolon.json"))
+	is.NoErr(err)
+	is.Equal(string(buf), `{"delimiter": ";", "doubleQuote": true}`)
 }
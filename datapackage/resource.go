@@ -1,7 +1,11 @@
 package datapackage
 
 import (
+	"bufio"
 	"bytes"
+	"compress/gzip"
+	"context"
+	stdcsv "encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,9 +15,10 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/frictionlessdata/datapackage-go/clone"
 	"github.com/frictionlessdata/datapackage-go/validator"
@@ -24,10 +29,11 @@ import (
 
 // Accepted tabular formats.
 var tabularFormats = map[string]struct{}{
-	"csv":  struct{}{},
-	"tsv":  struct{}{},
-	"xls":  struct{}{},
-	"xlsx": struct{}{},
+	"csv":        struct{}{},
+	"tsv":        struct{}{},
+	"xls":        struct{}{},
+	"xlsx":       struct{}{},
+	ndjsonFormat: struct{}{},
 }
 
 const (
@@ -49,17 +55,22 @@ const (
 	pathProp             = "path"
 	dataProp             = "data"
 	jsonFormat           = "json"
+	ndjsonFormat         = "ndjson"
 	profileProp          = "profile"
 	dialectProp          = "dialect"
 	delimiterProp        = "delimiter"
 	skipInitialSpaceProp = "skipInitialSpace"
 	headerProp           = "header"
 	doubleQuoteProp      = "doubleQuote"
+	quoteCharProp        = "quoteChar"
+	compressionProp      = "compression"
+	gzipCompression      = "gz"
+	noCompression        = "no"
 )
 
-// dialect represents CSV dialect configuration options.
+// Dialect represents CSV dialect configuration options.
 // http://frictionlessdata.io/specs/csv-dialect/
-type dialect struct {
+type Dialect struct {
 	// Delimiter specifies the character sequence which should separate fields (aka columns).
 	Delimiter rune
 	// Specifies how to interpret whitespace which immediately follows a delimiter;
@@ -69,13 +80,16 @@ type dialect struct {
 	Header bool
 	// Controls the handling of quotes inside fields. If true, two consecutive quotes should be interpreted as one.
 	DoubleQuote bool
+	// QuoteChar specifies a one-character string to use as the quoting character.
+	QuoteChar rune
 }
 
-var defaultDialect = dialect{
+var defaultDialect = Dialect{
 	Delimiter:        ',',
 	SkipInitialSpace: true,
 	Header:           true,
 	DoubleQuote:      true,
+	QuoteChar:        '"',
 }
 
 // Resource describes a data resource such as an individual file or table.
@@ -85,8 +99,21 @@ type Resource struct {
 	data       interface{}
 	name       string
 	basePath   string
+	// Opens relative paths. If nil, relative paths are read from the file system.
+	open openFunc
+	// Fetches remote paths. If nil, defaultHTTPClient is used.
+	client *http.Client
+	// Guards schema and dialect, which are loaded lazily and may be read concurrently.
+	mu sync.Mutex
+	// Schema loaded from the path or URL the schema property refers to.
+	schema map[string]interface{}
+	// Dialect loaded from the path or URL the dialect property refers to.
+	dialect map[string]interface{}
 }
 
+// openFunc opens the file at the passed-in relative path.
+type openFunc func(name string) (io.ReadCloser, error)
+
 // Name returns the resource name.
 func (r *Resource) Name() string {
 	return r.name
@@ -99,227 +126,1356 @@ func (r *Resource) Descriptor() map[string]interface{} {
 	return c
 }
 
-// Update the resource with the passed-in descriptor. The resource will only be updated if the
-// the new descriptor is valid, otherwise the error will be returned.
-func (r *Resource) Update(d map[string]interface{}, loaders ...validator.RegistryLoader) error {
-	reg, err := validator.NewRegistry(loaders...)
+// Update the resource with the passed-in descriptor. The resource will only be updated if the
+// the new descriptor is valid, otherwise the error will be returned.
+func (r *Resource) Update(d map[string]interface{}, loaders ...validator.RegistryLoader) error {
+	reg, err := validator.NewRegistry(loaders...)
+	if err != nil {
+		return err
+	}
+	res, err := newResource(context.Background(), d, r.basePath, r.open, r.client, reg)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.descriptor, r.path, r.data, r.name = res.descriptor, res.path, res.data, res.name
+	r.schema, r.dialect = nil, nil
+	return nil
+}
+
+// Validate checks the resource descriptor against its profile and the rules of the data resource
+// specification, returning all problems found as ValidationError values. Their field is the JSON
+// pointer to the invalid property (for instance, "/name") and their rule identifies the violated
+// rule (for instance, RuleNameFormat). Profiles are loaded from the passed-in registry loaders,
+// which default to the registry shipped with the library.
+func (r *Resource) Validate(loaders ...validator.RegistryLoader) []error {
+	reg, err := validator.NewRegistry(loaders...)
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range r.validate(reg) {
+		errs = append(errs, e)
+	}
+	return errs
+}
+
+func (r *Resource) validate(reg validator.Registry) []ValidationError {
+	cpy, err := clone.Descriptor(r.descriptor)
+	if err != nil {
+		return []ValidationError{{Rule: RuleResourceType, Message: err.Error()}}
+	}
+	fillResourceDescriptorWithDefaultValues(cpy)
+	var errs []ValidationError
+	reported := map[string]bool{}
+	report := func(e ValidationError) {
+		if !reported[e.Field] {
+			reported[e.Field] = true
+			errs = append(errs, e)
+		}
+	}
+	// Like NewResource, schemas referenced by path or URL are loaded before checking the profile.
+	if _, ok := cpy[schemaProp].(string); ok {
+		sch, err := r.Schema()
+		if err != nil {
+			report(ValidationError{Field: "/" + schemaProp, Rule: RuleSchemaLoad, Message: err.Error()})
+		} else {
+			cpy[schemaProp] = sch
+		}
+	}
+	profile, ok := cpy[profilePropName].(string)
+	if !ok {
+		report(ValidationError{Field: "/" + profilePropName, Rule: RuleProfile, Message: "profile property MUST be a string"})
+	} else if err := validator.Validate(withoutDialectReference(cpy), profile, reg); err != nil {
+		sErrs, ok := err.(validator.SchemaErrors)
+		if !ok {
+			return append(errs, ValidationError{Field: "/" + profilePropName, Rule: RuleProfile, Message: err.Error()})
+		}
+		var profileErrs []ValidationError
+		for _, e := range sErrs {
+			if !reported[e.Pointer] {
+				profileErrs = append(profileErrs, ValidationError{Field: e.Pointer, Rule: RuleProfile, Message: e.Message})
+			}
+		}
+		for _, e := range profileErrs {
+			reported[e.Field] = true
+			errs = append(errs, e)
+		}
+	}
+	// The checks below only report violations the profile has not reported already.
+	check := func(err error) {
+		if vErr, ok := err.(ValidationError); ok {
+			report(vErr)
+		}
+	}
+	_, err = parseName(cpy[nameProp])
+	check(err)
+	switch {
+	case cpy[pathProp] != nil && cpy[dataProp] != nil:
+		report(ValidationError{Field: "/" + dataProp, Rule: RulePathAndData, Message: "path and data properties MUST NOT be both provided"})
+	case cpy[pathProp] != nil:
+		_, err := parsePath(cpy[pathProp], cpy)
+		check(err)
+	case cpy[dataProp] != nil:
+		_, err := parseData(cpy[dataProp], cpy)
+		check(err)
+	default:
+		report(ValidationError{Field: "/" + pathProp, Rule: RulePathOrData, Message: "either path or data property MUST be provided"})
+	}
+	if dStr, ok := cpy[dialectProp].(string); ok {
+		check(parseDialectPath(dStr, cpy))
+	}
+	if cpy[compressionProp] != nil {
+		if _, err := r.compression(""); err != nil {
+			report(ValidationError{Field: "/" + compressionProp, Rule: RuleCompression, Message: err.Error()})
+		}
+	}
+	if cpy[schemaProp] != nil && !reported["/"+schemaProp] {
+		sch, err := r.GetSchema()
+		if err == nil {
+			err = sch.Validate()
+		}
+		if err != nil {
+			report(ValidationError{Field: "/" + schemaProp, Rule: RuleSchemaFormat, Message: err.Error()})
+		}
+	}
+	return errs
+}
+
+// Clone returns a deep copy of the resource, which shares no mutable state with the original.
+func (r *Resource) Clone() (*Resource, error) {
+	cpy, err := clone.Descriptor(r.descriptor)
+	if err != nil {
+		return nil, err
+	}
+	c := &Resource{descriptor: cpy, path: append([]string(nil), r.path...), name: r.name, basePath: r.basePath, open: r.open, client: r.client}
+	if r.data != nil {
+		c.data = cpy[dataProp]
+	}
+	// Loaded schemas and dialects are never modified, so they can be shared.
+	r.mu.Lock()
+	c.schema, c.dialect = r.schema, r.dialect
+	r.mu.Unlock()
+	return c, nil
+}
+
+// withBasePath returns the resource itself if basePath is empty, or else a copy of it which
+// resolves relative paths against basePath.
+func (r *Resource) withBasePath(basePath string) *Resource {
+	if basePath == "" {
+		return r
+	}
+	return &Resource{descriptor: r.descriptor, path: r.path, data: r.data, name: r.name, basePath: basePath, open: r.open, client: r.client}
+}
+
+// Format returns the resource format property. If it is not set, the format is inferred from
+// the extension of the first path (e.g. "csv" for "data.CSV") or from the resource mediatype.
+// An empty string is returned if the format can not be inferred.
+func (r *Resource) Format() string {
+	if fStr, ok := r.descriptor[formatProp].(string); ok && fStr != "" {
+		return fStr
+	}
+	return inferFormat(r.path, r.descriptor[mediaTypeProp])
+}
+
+// MediaType returns the resource mediatype property. If it is not set, the mediatype is derived
+// from the resource format (e.g. "text/csv" for "csv"). Unknown formats yield "application/octet-stream".
+func (r *Resource) MediaType() string {
+	if mStr, ok := r.descriptor[mediaTypeProp].(string); ok && mStr != "" {
+		return mStr
+	}
+	if m, ok := formatMediaTypes[strings.ToLower(r.Format())]; ok {
+		return m
+	}
+	return defaultMediaType
+}
+
+// SetFormat sets the resource format property. An empty format removes the property, so Format
+// goes back to inferring it.
+func (r *Resource) SetFormat(f string) {
+	r.setStringProp(formatProp, f)
+}
+
+// SetMediaType sets the resource mediatype property. An empty mediatype removes the property, so
+// MediaType goes back to deriving it from the format.
+func (r *Resource) SetMediaType(m string) {
+	r.setStringProp(mediaTypeProp, m)
+}
+
+// Encoding returns the character encoding of the resource contents, which defaults to "utf-8".
+func (r *Resource) Encoding() string {
+	if e, ok := r.descriptor[encodingPropName].(string); ok && e != "" {
+		return e
+	}
+	return defaultResourceEncoding
+}
+
+// Character set names, as registered by IANA. They are made of at most 40 printable US-ASCII characters.
+// https://tools.ietf.org/html/rfc2978#section-2.3
+var charsetRegexp = regexp.MustCompile(`^[A-Za-z0-9!#$%&'+\-^_\x60{}~.:()]{1,40}$`)
+
+// SetEncoding sets the character encoding of the resource contents (e.g. "utf-8" or "ISO-8859-1").
+// An error is returned if enc is not a valid character set name.
+func (r *Resource) SetEncoding(enc string) error {
+	if !charsetRegexp.MatchString(enc) {
+		return fmt.Errorf("invalid encoding %q: it MUST be a character set name registered by IANA", enc)
+	}
+	r.descriptor[encodingPropName] = enc
+	return nil
+}
+
+func (r *Resource) setStringProp(name, s string) {
+	if s == "" {
+		delete(r.descriptor, name)
+		return
+	}
+	r.descriptor[name] = s
+}
+
+// MarshalJSON returns the JSON encoding of the resource descriptor.
+func (r *Resource) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.descriptor)
+}
+
+// Tabular checks whether the resource is tabular, that is, whether its profile is tabular-data-resource
+// or its format, mediatype or path extensions are tabular ones (e.g. csv or tsv).
+func (r *Resource) Tabular() bool {
+	if pStr, ok := r.descriptor[profileProp].(string); ok && pStr == tabularDataResourceProfile {
+		return true
+	}
+	fStr, _ := r.descriptor[formatProp].(string)
+	if _, ok := tabularFormats[fStr]; ok {
+		return true
+	}
+	mStr, _ := r.descriptor[mediaTypeProp].(string)
+	if _, ok := tabularFormats[mediaTypeFormats[mStr]]; ok {
+		return true
+	}
+	if len(r.path) > 0 && all(r.path, isFileTabular) {
+		return true
+	}
+	return false
+}
+
+// IsTabular checks whether the resource descriptor declares tabular data, that is, whether it has
+// an inline table schema, a "csv" or "tsv" format, or a "text/csv" media type. Unlike Tabular, it
+// does not look at the profile nor the path extensions.
+func (r *Resource) IsTabular() bool {
+	if _, ok := r.descriptor[schemaProp].(map[string]interface{}); ok {
+		return true
+	}
+	switch f, _ := r.descriptor[formatProp].(string); strings.ToLower(f) {
+	case "csv", "tsv":
+		return true
+	}
+	m, _ := r.descriptor[mediaTypeProp].(string)
+	return strings.HasPrefix(strings.ToLower(m), "text/csv")
+}
+
+func all(strings []string, f func(string) bool) bool {
+	for _, s := range strings {
+		if !f(s) {
+			return false
+		}
+	}
+	return true
+}
+
+func isFileTabular(path string) bool {
+	path = strings.TrimSuffix(path, "."+gzipCompression)
+	for extension := range tabularFormats {
+		if strings.HasSuffix(path, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseDialect(i interface{}) Dialect {
+	d := defaultDialect
+	// Overriding default setting with valid values.
+	dMap, ok := i.(map[string]interface{})
+	if ok {
+		if v, ok := dMap[delimiterProp].(string); ok {
+			s := []rune(v)
+			if len(s) > 0 {
+				d.Delimiter = s[0]
+			}
+		}
+		if v, ok := dMap[skipInitialSpaceProp].(bool); ok {
+			d.SkipInitialSpace = v
+		}
+		if v, ok := dMap[headerProp].(bool); ok {
+			d.Header = v
+		}
+		if v, ok := dMap[doubleQuoteProp].(bool); ok {
+			d.DoubleQuote = v
+		}
+		if v, ok := dMap[quoteCharProp].(string); ok {
+			s := []rune(v)
+			if len(s) > 0 {
+				d.QuoteChar = s[0]
+			}
+		}
+	}
+	return d
+}
+
+func dialectOpts(dMap map[string]interface{}) []csv.CreationOpts {
+	if dMap == nil {
+		return []csv.CreationOpts{}
+	}
+	d := parseDialect(dMap)
+	// Mapping dialect to proper csv CreationOpts.
+	opts := []csv.CreationOpts{csv.Delimiter(d.Delimiter)}
+	if !d.SkipInitialSpace {
+		opts = append(opts, csv.ConsiderInitialSpace())
+	}
+	if d.Header {
+		opts = append(opts, csv.LoadHeaders())
+	}
+	return opts
+}
+
+// GetTable returns a table object to access the data. Returns an error if the resource is not tabular.
+func (r *Resource) GetTable(opts ...csv.CreationOpts) (table.Table, error) {
+	return r.getTable(context.Background(), opts...)
+}
+
+// getTable returns the resource table. Remote contents are fetched using ctx.
+func (r *Resource) getTable(ctx context.Context, opts ...csv.CreationOpts) (table.Table, error) {
+	if !r.Tabular() {
+		return nil, fmt.Errorf("methods iter/read are not supported for non tabular data")
+	}
+	if r.ndjson() {
+		return r.ndjsonTable(ctx)
+	}
+	dMap, err := r.dialectDescriptor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fullOpts := append(dialectOpts(dMap), opts...)
+	// Inlined resources.
+	if r.data != nil {
+		switch r.data.(type) {
+		case string:
+			return csv.NewTable(csv.FromString(r.data.(string)), fullOpts...)
+		default:
+			return nil, fmt.Errorf("only csv and string is supported for inlining data")
+		}
+	}
+	return csv.NewTable(func() (io.ReadCloser, error) { return r.tabularContents(ctx) }, fullOpts...)
+}
+
+// ndjson checks whether the resource contents are newline-delimited JSON.
+func (r *Resource) ndjson() bool {
+	return strings.EqualFold(r.Format(), ndjsonFormat)
+}
+
+// ndjsonTable returns the table of a newline-delimited JSON resource. Columns are the schema fields,
+// if the resource has a schema, or the keys of the first object otherwise. CSV options do not apply.
+func (r *Resource) ndjsonTable(ctx context.Context) (table.Table, error) {
+	t := &ndjsonTable{}
+	if r.descriptor[schemaProp] != nil {
+		sch, err := r.GetSchema()
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range sch.Fields {
+			t.headers = append(t.headers, f.Name)
+		}
+	}
+	switch data := r.data.(type) {
+	case nil:
+		t.source = func() (io.ReadCloser, error) {
+			return loadContents(r.basePath, r.path, withoutBOM(r.loadFunc(ctx, binaryLoadFunc)))
+		}
+	case string:
+		t.source = func() (io.ReadCloser, error) { return ioutil.NopCloser(strings.NewReader(data)), nil }
+	default:
+		return nil, fmt.Errorf("only csv and string is supported for inlining data")
+	}
+	return t, nil
+}
+
+// tabularContents opens the contents of the tabular resource path, concatenating multipart chunks.
+// UTF-8 byte order marks are stripped from the beginning of every chunk.
+func (r *Resource) tabularContents(ctx context.Context) (io.ReadCloser, error) {
+	f := withoutBOM(r.loadFunc(ctx, csvLoadFunc))
+	if len(r.path) > 1 {
+		dMap, err := r.dialectDescriptor(ctx)
+		if err != nil {
+			return nil, err
+		}
+		d := parseDialect(dMap)
+		return loadMultipartContents(r.basePath, r.path, d.Delimiter, d.Header, f)
+	}
+	return loadContents(r.basePath, r.path, f)
+}
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// withoutBOM wraps f, so the loaded contents do not start with the UTF-8 byte order mark. Otherwise,
+// the mark would become part of the first field name.
+func withoutBOM(f func(string) func() (io.ReadCloser, error)) func(string) func() (io.ReadCloser, error) {
+	return func(p string) func() (io.ReadCloser, error) {
+		return func() (io.ReadCloser, error) {
+			rc, err := f(p)()
+			if err != nil {
+				return nil, err
+			}
+			br := bufio.NewReader(rc)
+			if b, err := br.Peek(len(utf8BOM)); err == nil && bytes.Equal(b, utf8BOM) {
+				br.Discard(len(utf8BOM))
+			}
+			return &multiReadCloser{br, []io.ReadCloser{rc}}, nil
+		}
+	}
+}
+
+// loadFunc returns a function which loads remote paths using the resource HTTP client and
+// relative paths using the resource openFunc, if there is one. Otherwise, f is used.
+// Gzip-compressed contents are decompressed.
+func (r *Resource) loadFunc(ctx context.Context, f func(string) func() (io.ReadCloser, error)) func(string) func() (io.ReadCloser, error) {
+	return func(p string) func() (io.ReadCloser, error) {
+		var load func() (io.ReadCloser, error)
+		switch {
+		case strings.HasPrefix(p, "http"):
+			load = r.fetchFunc(ctx, p)
+		case r.open != nil:
+			load = func() (io.ReadCloser, error) { return r.open(p) }
+		default:
+			load = f(p)
+		}
+		c, err := r.compression(p)
+		if err != nil {
+			return func() (io.ReadCloser, error) { return nil, err }
+		}
+		if c == gzipCompression {
+			return gunzipLoadFunc(p, load)
+		}
+		return load
+	}
+}
+
+// compression returns how the contents of the passed-in path are compressed: gzipCompression if
+// the resource compression property is "gz" or, without that property, if the path has the ".gz"
+// extension, and noCompression otherwise. Unsupported compression properties are reported as errors.
+func (r *Resource) compression(p string) (string, error) {
+	cI := r.descriptor[compressionProp]
+	if cI == nil {
+		if strings.HasSuffix(strings.ToLower(p), "."+gzipCompression) {
+			return gzipCompression, nil
+		}
+		return noCompression, nil
+	}
+	c, ok := cI.(string)
+	if !ok || (c != gzipCompression && c != noCompression) {
+		return "", fmt.Errorf("unsupported compression \"%v\" of resource %s. Supported values are %q and %q", cI, r.name, gzipCompression, noCompression)
+	}
+	return c, nil
+}
+
+func gunzipLoadFunc(p string, load func() (io.ReadCloser, error)) func() (io.ReadCloser, error) {
+	return func() (io.ReadCloser, error) {
+		rc, err := load()
+		if err != nil {
+			return nil, err
+		}
+		gz, err := gzip.NewReader(rc)
+		if err != nil {
+			rc.Close()
+			return nil, fmt.Errorf("error decompressing %s: %w", p, err)
+		}
+		return &multiReadCloser{gz, []io.ReadCloser{gz, rc}}, nil
+	}
+}
+
+func (r *Resource) httpClient() *http.Client {
+	if r.client == nil {
+		return defaultHTTPClient
+	}
+	return r.client
+}
+
+// readPath returns the contents of one of the resource paths.
+func (r *Resource) readPath(p string) ([]byte, error) {
+	return readRelative(context.Background(), p, r.basePath, r.open, r.httpClient())
+}
+
+// readRelative reads the contents at the passed-in path. URLs are fetched using client, while
+// relative paths are opened with open, if not nil, or joined to basePath.
+func readRelative(ctx context.Context, p, basePath string, open openFunc, client *http.Client) ([]byte, error) {
+	if strings.HasPrefix(p, "http") {
+		return fetch(ctx, client, p)
+	}
+	if open != nil {
+		rc, err := open(p)
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return ioutil.ReadAll(rc)
+	}
+	if basePath != "" {
+		p = joinPaths(basePath, p)
+	}
+	if strings.HasPrefix(p, "http") {
+		return fetch(ctx, client, p)
+	}
+	return ioutil.ReadFile(p)
+}
+
+func csvLoadFunc(p string) func() (io.ReadCloser, error) {
+	if strings.HasPrefix(p, "http") {
+		return remoteLoadFunc(context.Background(), defaultHTTPClient, p)
+	}
+	return csv.FromFile(p)
+}
+
+func binaryLoadFunc(p string) func() (io.ReadCloser, error) {
+	if strings.HasPrefix(p, "http") {
+		return remoteLoadFunc(context.Background(), defaultHTTPClient, p)
+	}
+	return func() (io.ReadCloser, error) {
+		return os.Open(p)
+	}
+}
+
+func remoteLoadFunc(ctx context.Context, client *http.Client, url string) func() (io.ReadCloser, error) {
+	return func() (io.ReadCloser, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkStatus(resp, url); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		return resp.Body, nil
+	}
+}
+
+// fetchFunc is like remoteLoadFunc, but it uses the resource HTTP client and, if ctx is done,
+// request and read errors are replaced by ctx.Err(), wrapped with the name of the resource.
+func (r *Resource) fetchFunc(ctx context.Context, url string) func() (io.ReadCloser, error) {
+	return func() (io.ReadCloser, error) {
+		rc, err := remoteLoadFunc(ctx, r.httpClient(), url)()
+		if err != nil {
+			return nil, r.ctxError(ctx, err)
+		}
+		return &ctxReadCloser{rc, func(err error) error { return r.ctxError(ctx, err) }}, nil
+	}
+}
+
+func (r *Resource) ctxError(ctx context.Context, err error) error {
+	if err == nil || err == io.EOF || ctx.Err() == nil {
+		return err
+	}
+	return fmt.Errorf("error fetching resource %s:%w", r.name, ctx.Err())
+}
+
+// ctxReadCloser replaces read errors using wrap.
+type ctxReadCloser struct {
+	io.ReadCloser
+	wrap func(error) error
+}
+
+func (c *ctxReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	return n, c.wrap(err)
+}
+
+type multiReadCloser struct {
+	io.Reader
+	rcs []io.ReadCloser
+}
+
+func (m *multiReadCloser) Close() error {
+	var err error
+	for _, rc := range m.rcs {
+		if e := rc.Close(); e != nil {
+			err = e
+		}
+	}
+	return err
+}
+
+func newMultiReadCloser(rcs []io.ReadCloser) io.ReadCloser {
+	readers := make([]io.Reader, len(rcs))
+	for i := range rcs {
+		readers[i] = io.Reader(rcs[i])
+	}
+	return &multiReadCloser{io.MultiReader(readers...), rcs}
+}
+
+func loadContents(basePath string, path []string, f func(string) func() (io.ReadCloser, error)) (io.ReadCloser, error) {
+	var rcs []io.ReadCloser
+	for _, p := range path {
+		if basePath != "" {
+			p = joinPaths(basePath, p)
+		}
+		rc, err := f(p)()
+		if err != nil {
+			return nil, err
+		}
+		rcs = append(rcs, rc)
+		if len(path) > 1 {
+			rcs = append(rcs, ioutil.NopCloser(bytes.NewReader([]byte{'\n'})))
+		}
+	}
+	return newMultiReadCloser(rcs), nil
+}
+
+// loadMultipartContents concatenates the chunks of a tabular multipart resource, which are checked
+// as described in multipartChunks.
+func loadMultipartContents(basePath string, path []string, delimiter rune, header bool, f func(string) func() (io.ReadCloser, error)) (io.ReadCloser, error) {
+	chunks := &multipartChunks{path: path, delimiter: delimiter, header: header}
+	var rcs []io.ReadCloser
+	for i, p := range path {
+		if basePath != "" {
+			p = joinPaths(basePath, p)
+		}
+		rc, err := f(p)()
+		if err != nil {
+			newMultiReadCloser(rcs).Close()
+			return nil, err
+		}
+		rcs = append(rcs, rc)
+		chunk, err := chunks.read(i, rc)
+		if err != nil {
+			newMultiReadCloser(rcs).Close()
+			return nil, err
+		}
+		// Replacing the raw chunk by a reader which takes into account the first line.
+		rcs[len(rcs)-1] = &multiReadCloser{chunk, []io.ReadCloser{rc}}
+		rcs = append(rcs, ioutil.NopCloser(bytes.NewReader([]byte{'\n'})))
+	}
+	return newMultiReadCloser(rcs), nil
+}
+
+// multipartChunks checks the chunks of a tabular multipart resource as they are read. As per
+// the specs, all chunks must share the same structure, which means they must have the same
+// number of columns. If the chunks have a header row, header rows repeated at the beginning of the
+// following chunks are skipped.
+type multipartChunks struct {
+	path      []string
+	delimiter rune
+	header    bool
+	first     []string
+}
+
+// read returns the contents of the i-th chunk, which is read from rc. Chunks must be read in order.
+func (m *multipartChunks) read(i int, rc io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(rc)
+	firstLine, err := br.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	fields, err := parseCSVLine(firstLine, m.delimiter)
+	if err != nil {
+		return nil, fmt.Errorf("error reading multipart resource chunk %s: %w", m.path[i], err)
+	}
+	if i == 0 {
+		m.first = fields
+	} else {
+		if len(fields) != len(m.first) {
+			return nil, fmt.Errorf("multipart resource chunk %s has %d columns, want %d", m.path[i], len(fields), len(m.first))
+		}
+		if m.header && reflect.DeepEqual(fields, m.first) {
+			firstLine = ""
+		}
+	}
+	return io.MultiReader(strings.NewReader(firstLine), br), nil
+}
+
+func parseCSVLine(line string, delimiter rune) ([]string, error) {
+	r := stdcsv.NewReader(strings.NewReader(line))
+	r.Comma = delimiter
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	fields, err := r.Read()
+	if err == io.EOF {
+		return []string{}, nil
+	}
+	return fields, err
+}
+
+func joinPaths(basePath, path string) string {
+	if strings.HasPrefix(path, "http") {
+		return path
+	}
+	u, err := url.Parse(basePath)
+	if err != nil {
+		return filepath.Join(basePath, path)
+	}
+	u.Path = filepath.Join(u.EscapedPath(), path)
+	return u.String()
+}
+
+// FullPaths returns the resource paths resolved against the resource base path, which is set
+// when the package is created (e.g. the directory or URL of the descriptor loaded by Load and FromURL).
+// Relative paths are joined to local base paths and resolved against remote ones, while URLs are
+// returned untouched. An error is returned if a resolved path would escape the base path.
+func (r *Resource) FullPaths() ([]string, error) {
+	full := make([]string, len(r.path))
+	for i, p := range r.path {
+		if strings.HasPrefix(p, "http") {
+			full[i] = p
+			continue
+		}
+		fp, err := resolvePath(r.basePath, p)
+		if err != nil {
+			return nil, err
+		}
+		full[i] = fp
+	}
+	return full, nil
+}
+
+// resolvePath joins the relative path p to basePath, which could be either a directory or an URL.
+func resolvePath(basePath, p string) (string, error) {
+	cleaned, ok := cleanRelativePath(p)
+	if !ok {
+		return "", fmt.Errorf("path %s escapes the base path %s", p, basePath)
+	}
+	if u, err := url.Parse(basePath); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		u.Path = path.Join(u.Path, cleaned)
+		return u.String(), nil
+	}
+	joined := filepath.Join(basePath, filepath.FromSlash(cleaned))
+	// Checking again after joining, so no base path peculiarity lets the path out.
+	if rel, err := filepath.Rel(filepath.Clean(basePath), joined); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s escapes the base path %s", p, basePath)
+	}
+	return joined, nil
+}
+
+// cleanRelativePath cleans p using forward slashes as separators, no matter the operating system, so
+// Windows separators can not be used to sneak in parent references. It returns false if the cleaned
+// path is absolute or refers to a parent directory.
+func cleanRelativePath(p string) (string, bool) {
+	cleaned := path.Clean(strings.Replace(p, "\\", "/", -1))
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", false
+	}
+	return cleaned, true
+}
+
+// ReadAll reads all rows from the table and return it as strings.
+func (r *Resource) ReadAll(opts ...csv.CreationOpts) ([][]string, error) {
+	return r.ReadAllContext(context.Background(), opts...)
+}
+
+// ReadAllContext is like ReadAll, but remote contents are fetched using the passed-in context.
+// Cancelling the context aborts the download and the returned error wraps ctx.Err().
+func (r *Resource) ReadAllContext(ctx context.Context, opts ...csv.CreationOpts) ([][]string, error) {
+	t, err := r.getTable(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// Not using t.ReadAll because it ignores errors happened while reading the contents, for
+	// instance, aborted downloads.
+	iter, err := t.Iter()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var rows [][]string
+	for iter.Next() {
+		rows = append(rows, iter.Row())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Dialect returns the CSV dialect of the resource. Properties which are not set in the
+// resource descriptor take their default values. If the dialect property is a path or URL, the
+// dialect is loaded the same way the resource schema is; default values are returned if it can
+// not be loaded, and reading the contents reports the error.
+func (r *Resource) Dialect() Dialect {
+	dMap, _ := r.dialectDescriptor(context.Background())
+	return parseDialect(dMap)
+}
+
+// dialectDescriptor returns the dialect declared by the resource, or nil if there is none. When the
+// dialect property is a path or URL, the dialect is loaded and cached, so it is fetched only once.
+func (r *Resource) dialectDescriptor(ctx context.Context) (map[string]interface{}, error) {
+	switch d := r.descriptor[dialectProp].(type) {
+	case map[string]interface{}:
+		return d, nil
+	case string:
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.dialect == nil {
+			buf, err := readRelative(ctx, d, r.basePath, r.open, r.httpClient())
+			var dMap map[string]interface{}
+			if err == nil {
+				err = json.Unmarshal(buf, &dMap)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error loading dialect %s of resource %s: %w", d, r.name, err)
+			}
+			r.dialect = dMap
+		}
+		return r.dialect, nil
+	}
+	return nil, nil
+}
+
+// relativeFiles returns the relative paths of the files the resource refers to, that is, its data
+// and the dialect, if referenced by path.
+func (r *Resource) relativeFiles() []string {
+	var files []string
+	for _, p := range r.path {
+		if !strings.HasPrefix(p, "http") {
+			files = append(files, p)
+		}
+	}
+	if dStr, ok := r.descriptor[dialectProp].(string); ok && !strings.HasPrefix(dStr, "http") {
+		files = append(files, dStr)
+	}
+	return files
+}
+
+// withoutDialectReference returns a shallow copy of the resource descriptor d without the dialect
+// property if it is a path or URL. Profiles only allow inline dialects, while references are
+// checked by parseDialectPath and resolved at read time.
+func withoutDialectReference(d map[string]interface{}) map[string]interface{} {
+	if _, ok := d[dialectProp].(string); !ok {
+		return d
+	}
+	cpy := make(map[string]interface{}, len(d))
+	for k, v := range d {
+		if k != dialectProp {
+			cpy[k] = v
+		}
+	}
+	return cpy
+}
+
+// parseDialectPath checks the dialect path or URL the same way resource paths are checked.
+func parseDialectPath(p string, d map[string]interface{}) error {
+	if _, err := parsePath(p, d); err != nil {
+		if vErr, ok := err.(ValidationError); ok {
+			vErr.Field = "/" + dialectProp
+			return vErr
+		}
+		return err
+	}
+	return nil
+}
+
+// ReadRows reads the tabular resource contents honoring its CSV dialect. Contents are comma-delimited
+// and start with a header row unless the dialect says otherwise. The header row is not returned.
+// As encoding/csv only supports double quotes, quotes are parsed leniently if the dialect uses a
+// different quote character or disables doubleQuote. Rows of newline-delimited JSON resources hold
+// the values of the schema fields or, if there is no schema, of the keys of the first object.
+// Large contents could be read one row at a time using Iterate.
+func (r *Resource) ReadRows() ([][]string, error) {
+	iter, err := r.Iterate()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var rows [][]string
+	for iter.Next() {
+		rows = append(rows, iter.Row())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Iterate returns an iterator over the rows of the tabular resource, which streams the contents and
+// reads them one row at a time. Rows are read as in ReadRows, which means the header row is skipped.
+// The contents are closed once all rows have been read, an error happens or Close is called.
+func (r *Resource) Iterate() (*RowIterator, error) {
+	if !r.Tabular() {
+		return nil, fmt.Errorf("methods iter/read are not supported for non tabular data")
+	}
+	ctx := context.Background()
+	if r.ndjson() {
+		t, err := r.ndjsonTable(ctx)
+		if err != nil {
+			return nil, err
+		}
+		iter, err := t.Iter()
+		if err != nil {
+			return nil, err
+		}
+		read := func() ([]string, error) {
+			if iter.Next() {
+				return iter.Row(), nil
+			}
+			if err := iter.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		return &RowIterator{read: read, closer: iter}, nil
+	}
+	dMap, err := r.dialectDescriptor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d := parseDialect(dMap)
+	var rc io.ReadCloser
+	switch {
+	case r.data == nil:
+		rc, err = r.tabularContents(ctx)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		s, ok := r.data.(string)
+		if !ok {
+			return nil, fmt.Errorf("only csv and string is supported for inlining data")
+		}
+		rc = ioutil.NopCloser(strings.NewReader(s))
+	}
+	cr := stdcsv.NewReader(rc)
+	cr.Comma = d.Delimiter
+	cr.TrimLeadingSpace = d.SkipInitialSpace
+	cr.LazyQuotes = d.QuoteChar != '"' || !d.DoubleQuote
+	if d.Header {
+		if _, err := cr.Read(); err != nil && err != io.EOF {
+			rc.Close()
+			return nil, err
+		}
+	}
+	return &RowIterator{read: cr.Read, closer: rc}, nil
+}
+
+// RowIterator reads the rows of a tabular resource one at a time. See Resource.Iterate.
+type RowIterator struct {
+	read   func() ([]string, error)
+	closer io.Closer
+	closed bool
+	row    []string
+	err    error
+}
+
+// Next advances the iterator to the next row, which is then available through Row. It returns false
+// when the iteration stops, either by reaching the end of the contents or an error (see Err). The
+// contents are closed when the iteration stops.
+func (i *RowIterator) Next() bool {
+	if i.closed {
+		return false
+	}
+	row, err := i.read()
+	if err != nil {
+		if err != io.EOF {
+			i.err = err
+		}
+		if cErr := i.Close(); i.err == nil {
+			i.err = cErr
+		}
+		return false
+	}
+	i.row = row
+	return true
+}
+
+// Row returns the row read by the last call to Next.
+func (i *RowIterator) Row() []string {
+	return i.row
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (i *RowIterator) Err() error {
+	return i.err
+}
+
+// Close closes the contents, stopping the iteration. It is safe to call Close more than once.
+func (i *RowIterator) Close() error {
+	if i.closed {
+		return nil
+	}
+	i.closed = true
+	return i.closer.Close()
+}
+
+// ReadOption configures how ReadObjectsTyped reads the resource contents.
+type ReadOption func(*readConfig)
+
+type readConfig struct {
+	checkPrimaryKey bool
+}
+
+// WithPrimaryKeyCheck makes ReadObjectsTyped check the primary key declared by the resource schema,
+// if any: a *PrimaryKeyError is returned for the first row with a null key value or repeating the
+// key of a previous row.
+func WithPrimaryKeyCheck() ReadOption {
+	return func(cfg *readConfig) {
+		cfg.checkPrimaryKey = true
+	}
+}
+
+// ReadObjectsTyped reads the tabular resource contents like ReadRows and returns each row as an
+// object keyed by the schema field names, with cells matched to fields by position. Cells are cast
+// to the type of their fields, for instance, integer fields become int64, numbers float64, booleans
+// bool (honoring trueValues and falseValues) and dates time.Time. Cells holding one of the schema
+// missingValues ("" by default) become nil, unless the field is required. A *CastError is returned
+// if a cell can not be cast.
+func (r *Resource) ReadObjectsTyped(opts ...ReadOption) ([]map[string]interface{}, error) {
+	var cfg readConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	sch, err := r.castSchema()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.ReadRows()
+	if err != nil {
+		return nil, err
+	}
+	// Maps primary keys to the position of the first row holding them.
+	keys := map[string]int{}
+	objs := make([]map[string]interface{}, len(rows))
+	for i, row := range rows {
+		obj, err := castRow(sch, i, row)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.checkPrimaryKey && len(sch.PrimaryKeys) > 0 {
+			if err := checkPrimaryKey(keys, sch.PrimaryKeys, i, obj); err != nil {
+				return nil, err
+			}
+		}
+		objs[i] = obj
+	}
+	return objs, nil
+}
+
+// checkPrimaryKey checks that the key of the passed-in row has no null values and has not been
+// seen before, recording it in keys.
+func checkPrimaryKey(keys map[string]int, fields []string, row int, obj map[string]interface{}) error {
+	vals := make([]interface{}, len(fields))
+	for i, f := range fields {
+		if obj[f] == nil {
+			return &PrimaryKeyError{Row: row, Fields: fields, NullField: f}
+		}
+		vals[i] = obj[f]
+	}
+	key := fmt.Sprintf("%#v", vals)
+	if first, ok := keys[key]; ok {
+		return &PrimaryKeyError{Row: row, Fields: fields, FirstRow: first}
+	}
+	keys[key] = row
+	return nil
+}
+
+// castSchema returns the resource schema, with the missing values copied to its fields.
+func (r *Resource) castSchema() (*schema.Schema, error) {
+	schMap, err := r.Schema()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if schMap == nil {
+		return nil, ErrNoSchema
+	}
+	if schMap["missingValues"] == nil {
+		schMap["missingValues"] = []interface{}{""}
 	}
-	res, err := NewResource(d, reg)
+	// Foreign keys are not needed to cast values, and tableschema-go does not support arrays of them.
+	delete(schMap, "foreignKeys")
+	buf, err := json.Marshal(schMap)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	*r = *res
-	return nil
+	return schema.Read(bytes.NewReader(buf))
 }
 
-// Tabular checks whether the resource is tabular.
-func (r *Resource) Tabular() bool {
-	if pStr, ok := r.descriptor[profileProp].(string); ok && pStr == tabularDataResourceProfile {
-		return true
+// castRow casts the cells of the i-th row to the types of the schema fields, matching them by position.
+func castRow(sch *schema.Schema, i int, row []string) (map[string]interface{}, error) {
+	if len(row) != len(sch.Fields) {
+		return nil, fmt.Errorf("row %d has %d cells, but the schema has %d fields", i, len(row), len(sch.Fields))
 	}
-	fStr, _ := r.descriptor[formatProp].(string)
-	if _, ok := tabularFormats[fStr]; ok {
-		return true
+	obj := make(map[string]interface{}, len(row))
+	for j, cell := range row {
+		f := &sch.Fields[j]
+		v, err := castCell(f, cell)
+		if err != nil {
+			return nil, &CastError{Row: i, Field: f.Name, Value: cell, Err: err}
+		}
+		obj[f.Name] = v
 	}
-	if len(r.path) > 0 && all(r.path, isFileTabular) {
-		return true
+	return obj, nil
+}
+
+func castCell(f *schema.Field, cell string) (interface{}, error) {
+	if _, ok := f.MissingValues[cell]; ok && !f.Constraints.Required {
+		return nil, nil
 	}
-	return false
+	return f.Cast(cell)
 }
 
-func all(strings []string, f func(string) bool) bool {
-	for _, s := range strings {
-		if !f(s) {
-			return false
+// RawRead returns an io.ReaderCloser associated to the resource contents.
+// It can be used to access the content of non-tabular resources. Inlined JSON
+// data (objects or arrays) is returned serialized as JSON. Every call returns
+// a new reader, which starts at the beginning of the contents. Gzip-compressed
+// contents are decompressed.
+func (r *Resource) RawRead() (io.ReadCloser, error) {
+	return r.RawReadContext(context.Background())
+}
+
+// RawReadContext is like RawRead, but remote contents are fetched using the passed-in context.
+// Cancelling the context aborts the download, including reads from the returned reader, and
+// the returned error wraps ctx.Err().
+func (r *Resource) RawReadContext(ctx context.Context) (io.ReadCloser, error) {
+	if r.data != nil {
+		if s, ok := r.data.(string); ok {
+			return ioutil.NopCloser(strings.NewReader(s)), nil
+		}
+		b, err := json.Marshal(r.data)
+		if err != nil {
+			return nil, err
 		}
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
 	}
-	return true
+	return loadContents(r.basePath, r.path, r.loadFunc(ctx, binaryLoadFunc))
 }
 
-func isFileTabular(path string) bool {
-	for extension := range tabularFormats {
-		if strings.HasSuffix(path, extension) {
-			return true
-		}
+// Bytes reads and returns the whole resource contents, as RawRead does. Relative paths are resolved
+// against basePath if it is not empty, or else against the resource base path. Inlined JSON data is
+// returned serialized as JSON.
+func (r *Resource) Bytes(basePath string) ([]byte, error) {
+	rc, err := r.withBasePath(basePath).RawRead()
+	if err != nil {
+		return nil, err
 	}
-	return false
+	defer rc.Close()
+	return ioutil.ReadAll(rc)
 }
 
-func dialectOpts(i interface{}) []csv.CreationOpts {
-	if i == nil {
-		return []csv.CreationOpts{}
+// ReadCloser returns a reader which streams the resource contents. Multipart resources are read in
+// sequence, each part being opened only when the previous one has been consumed, so contents are
+// never buffered as a whole. Parts of tabular resources are separated by line breaks and, if the
+// dialect says the contents have a header row, header rows repeated at the beginning of the following
+// parts are skipped. Reading fails if a part of a tabular resource does not have as many columns
+// as the first one. Parts of other resources are concatenated as they are.
+func (r *Resource) ReadCloser() (io.ReadCloser, error) {
+	if r.data != nil {
+		return r.RawRead()
 	}
-	d := defaultDialect
-	// Overriding default setting with valid values.
-	dMap, ok := i.(map[string]interface{})
-	if ok {
-		if v, ok := dMap[delimiterProp].(string); ok {
-			s := []rune(v)
-			if len(s) > 0 {
-				d.Delimiter = s[0]
-			}
-		}
-		if v, ok := dMap[skipInitialSpaceProp].(bool); ok {
-			d.SkipInitialSpace = v
-		}
-		if v, ok := dMap[headerProp].(bool); ok {
-			d.Header = v
+	load := r.loadFunc(context.Background(), binaryLoadFunc)
+	tabular := r.Tabular()
+	if tabular {
+		load = withoutBOM(r.loadFunc(context.Background(), csvLoadFunc))
+	}
+	pr := &partsReader{}
+	for _, p := range r.path {
+		if r.basePath != "" {
+			p = joinPaths(r.basePath, p)
 		}
+		pr.parts = append(pr.parts, load(p))
 	}
-	// Mapping dialect to proper csv CreationOpts.
-	opts := []csv.CreationOpts{csv.Delimiter(d.Delimiter)}
-	if !d.SkipInitialSpace {
-		opts = append(opts, csv.ConsiderInitialSpace())
+	if !tabular || len(r.path) < 2 {
+		return pr, nil
 	}
-	if d.Header {
-		opts = append(opts, csv.LoadHeaders())
+	dMap, err := r.dialectDescriptor(context.Background())
+	if err != nil {
+		return nil, err
 	}
-	return opts
-}
-
-// GetTable returns a table object to access the data. Returns an error if the resource is not tabular.
-func (r *Resource) GetTable(opts ...csv.CreationOpts) (table.Table, error) {
-	if !r.Tabular() {
-		return nil, fmt.Errorf("methods iter/read are not supported for non tabular data")
+	d := parseDialect(dMap)
+	var chunks *multipartChunks
+	// Lines of newline-delimited JSON are not checked, as they have no columns.
+	if !r.ndjson() {
+		chunks = &multipartChunks{path: r.path, delimiter: d.Delimiter, header: d.Header}
 	}
-	fullOpts := append(dialectOpts(r.descriptor[dialectProp]), opts...)
-	// Inlined resources.
-	if r.data != nil {
-		switch r.data.(type) {
-		case string:
-			return csv.NewTable(csv.FromString(r.data.(string)), fullOpts...)
-		default:
-			return nil, fmt.Errorf("only csv and string is supported for inlining data")
+	pr.prepare = func(i int, rc io.Reader) (io.Reader, error) {
+		var sep io.Reader = strings.NewReader("")
+		if i > 0 {
+			sep = strings.NewReader("\n")
+		}
+		if chunks == nil {
+			return io.MultiReader(sep, rc), nil
+		}
+		chunk, err := chunks.read(i, rc)
+		if err != nil {
+			return nil, err
 		}
+		return io.MultiReader(sep, chunk), nil
 	}
-	return csv.NewTable(func() (io.ReadCloser, error) { return loadContents(r.basePath, r.path, csvLoadFunc) }, fullOpts...)
+	return pr, nil
 }
 
-func csvLoadFunc(p string) func() (io.ReadCloser, error) {
-	if strings.HasPrefix(p, "http") {
-		return csv.Remote(p)
-	}
-	return csv.FromFile(p)
+// partsReader reads parts in sequence, opening each part only when the previous one has been consumed.
+type partsReader struct {
+	parts []func() (io.ReadCloser, error)
+	// Optionally changes the contents of the i-th part.
+	prepare func(i int, rc io.Reader) (io.Reader, error)
+	next    int
+	cur     io.Reader
+	curRC   io.ReadCloser
 }
 
-const (
-	remoteFetchTimeout = 15 * time.Second
-)
-
-var (
-	httpClient      *http.Client
-	startHTTPClient sync.Once
-)
-
-func binaryLoadFunc(p string) func() (io.ReadCloser, error) {
-	if strings.HasPrefix(p, "http") {
-		return func() (io.ReadCloser, error) {
-			startHTTPClient.Do(func() {
-				httpClient = &http.Client{
-					Timeout: remoteFetchTimeout,
-				}
-			})
-			resp, err := httpClient.Get(p)
+func (pr *partsReader) Read(b []byte) (int, error) {
+	for {
+		if pr.cur == nil {
+			if pr.next >= len(pr.parts) {
+				return 0, io.EOF
+			}
+			rc, err := pr.parts[pr.next]()
 			if err != nil {
-				return nil, err
+				return 0, err
 			}
-			return resp.Body, nil
+			var cur io.Reader = rc
+			if pr.prepare != nil {
+				if cur, err = pr.prepare(pr.next, rc); err != nil {
+					rc.Close()
+					return 0, err
+				}
+			}
+			pr.next++
+			pr.cur, pr.curRC = cur, rc
 		}
+		n, err := pr.cur.Read(b)
+		if err == io.EOF {
+			if err := pr.closePart(); err != nil {
+				return n, err
+			}
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
 	}
-	return func() (io.ReadCloser, error) {
-		return os.Open(p)
-	}
-}
-
-type multiReadCloser struct {
-	io.Reader
-	rcs []io.ReadCloser
 }
 
-func (m *multiReadCloser) Close() error {
-	var err error
-	for _, rc := range m.rcs {
-		if e := rc.Close(); e != nil {
-			err = e
-		}
+func (pr *partsReader) closePart() error {
+	if pr.curRC == nil {
+		return nil
 	}
+	err := pr.curRC.Close()
+	pr.cur, pr.curRC = nil, nil
 	return err
 }
 
-func newMultiReadCloser(rcs []io.ReadCloser) io.ReadCloser {
-	readers := make([]io.Reader, len(rcs))
-	for i := range rcs {
-		readers[i] = io.Reader(rcs[i])
-	}
-	return &multiReadCloser{io.MultiReader(readers...), rcs}
+// Close closes the part being read. The following parts are not read anymore.
+func (pr *partsReader) Close() error {
+	pr.next = len(pr.parts)
+	return pr.closePart()
 }
 
-func loadContents(basePath string, path []string, f func(string) func() (io.ReadCloser, error)) (io.ReadCloser, error) {
-	var rcs []io.ReadCloser
-	for _, p := range path {
-		if basePath != "" {
-			p = joinPaths(basePath, p)
+// ReadObjects returns the resource data as a slice of JSON objects. Inline arrays are returned as copies,
+// while JSON strings and files are unmarshalled first. An *ObjectsError is returned if the data is not
+// a JSON array of objects.
+func (r *Resource) ReadObjects() ([]map[string]interface{}, error) {
+	data := r.data
+	switch d := data.(type) {
+	case string:
+		if err := json.Unmarshal([]byte(d), &data); err != nil {
+			return nil, fmt.Errorf("error parsing inline data: %w", err)
 		}
-		rc, err := f(p)()
+	case nil:
+	default:
+		// Copying inline data, so changes to the returned objects do not leak into the descriptor.
+		c, err := clone.Descriptor(map[string]interface{}{dataProp: d})
 		if err != nil {
 			return nil, err
 		}
-		rcs = append(rcs, rc)
-		if len(path) > 1 {
-			rcs = append(rcs, ioutil.NopCloser(bytes.NewReader([]byte{'\n'})))
-		}
+		data = c[dataProp]
 	}
-	return newMultiReadCloser(rcs), nil
-}
-
-func joinPaths(basePath, path string) string {
-	u, err := url.Parse(basePath)
-	if err != nil {
-		return filepath.Join(basePath, path)
+	if data == nil {
+		rc, err := r.RawRead()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		b, err := ioutil.ReadAll(rc)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &data); err != nil {
+			return nil, fmt.Errorf("error parsing data: %w", err)
+		}
 	}
-	u.Path = filepath.Join(u.EscapedPath(), path)
-	return u.String()
-}
-
-// ReadAll reads all rows from the table and return it as strings.
-func (r *Resource) ReadAll(opts ...csv.CreationOpts) ([][]string, error) {
-	t, err := r.GetTable(opts...)
-	if err != nil {
-		return nil, err
+	items, ok := data.([]interface{})
+	if !ok {
+		return nil, &ObjectsError{Index: -1, Type: fmt.Sprintf("%T", data)}
 	}
-	return t.ReadAll()
-}
-
-// RawRead returns an io.ReaderCloser associated to the resource contents.
-// It can be used to access the content of non-tabular resources.
-func (r *Resource) RawRead() (io.ReadCloser, error) {
-	if r.data != nil {
-		return ioutil.NopCloser(bytes.NewReader([]byte(r.data.(string)))), nil
+	objs := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, &ObjectsError{Index: i, Type: fmt.Sprintf("%T", item)}
+		}
+		objs[i] = obj
 	}
-	return loadContents(r.basePath, r.path, binaryLoadFunc)
+	return objs, nil
 }
 
 // Iter returns an Iterator to read the tabular resource. Iter returns an error
 // if the table physical source can not be iterated.
 // The iteration process always start at the beginning of the table.
 func (r *Resource) Iter(opts ...csv.CreationOpts) (table.Iterator, error) {
-	t, err := r.GetTable(opts...)
+	return r.IterContext(context.Background(), opts...)
+}
+
+// IterContext is like Iter, but remote contents are fetched using the passed-in context.
+// Cancelling the context aborts the download and the iterator error wraps ctx.Err().
+func (r *Resource) IterContext(ctx context.Context, opts ...csv.CreationOpts) (table.Iterator, error) {
+	t, err := r.getTable(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return t.Iter()
 }
 
+// Schema returns a copy of the table schema declared by the resource, or nil if there is none.
+// When the schema property is a path or URL, the schema is loaded the same way the resource data
+// is, so relative paths are resolved against the base path. The loaded schema is cached, which
+// means it is fetched only once.
+func (r *Resource) Schema() (map[string]interface{}, error) {
+	var schMap map[string]interface{}
+	switch s := r.descriptor[schemaProp].(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		schMap = s
+	case string:
+		r.mu.Lock()
+		if r.schema == nil {
+			loaded, err := loadResourceSchema(context.Background(), s, r.name, r.basePath, r.open, r.httpClient())
+			if err != nil {
+				r.mu.Unlock()
+				return nil, err
+			}
+			r.schema = loaded
+		}
+		schMap = r.schema
+		r.mu.Unlock()
+	default:
+		return nil, fmt.Errorf("schema property MUST be either an object or a string:%v", s)
+	}
+	return clone.Descriptor(schMap)
+}
+
 // GetSchema returns the schema associated to the resource, if present. The returned
 // schema is based on a copy of the descriptor. Changes to it won't affect the data package
-// descriptor structure.
+// descriptor structure. ErrNoSchema is returned if the resource has no schema. Schemas
+// referenced by path or URL are loaded as in Schema.
 func (r *Resource) GetSchema() (schema.Schema, error) {
 	if r.descriptor[schemaProp] == nil {
-		return schema.Schema{}, fmt.Errorf("schema is not declared in the descriptor")
+		return schema.Schema{}, ErrNoSchema
+	}
+	schMap, err := r.Schema()
+	if err != nil {
+		return schema.Schema{}, err
 	}
-	buf, err := json.Marshal(r.descriptor[schemaProp])
+	buf, err := json.Marshal(schMap)
 	if err != nil {
 		return schema.Schema{}, err
 	}
@@ -328,6 +1484,87 @@ func (r *Resource) GetSchema() (schema.Schema, error) {
 	return s, nil
 }
 
+// Types considered when inferring table schemas, ordered by precedence.
+var inferredTypes = []schema.FieldType{schema.IntegerType, schema.NumberType, schema.BooleanType, schema.DateType}
+
+// Formats inferred from media types.
+var mediaTypeFormats = map[string]string{
+	"text/csv":                  "csv",
+	"text/tab-separated-values": "tsv",
+	"application/json":          "json",
+	"application/x-ndjson":      ndjsonFormat,
+}
+
+// Media types of known formats.
+var formatMediaTypes = map[string]string{
+	"csv":    "text/csv",
+	"tsv":    "text/tab-separated-values",
+	"json":   "application/json",
+	"ndjson": "application/x-ndjson",
+	"xls":    "application/vnd.ms-excel",
+	"xlsx":   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+const defaultMediaType = "application/octet-stream"
+
+// Infer infers the resource format, from its path extension or media type, and the
+// table schema of tabular resources. The schema field names come from the header row and
+// field types are guessed from a sample of the resource contents (integer, number, boolean,
+// date or string). Properties which are already set in the descriptor are not overwritten.
+func (r *Resource) Infer() error {
+	if r.descriptor[formatProp] == nil {
+		if f := inferFormat(r.path, r.descriptor[mediaTypeProp]); f != "" {
+			r.descriptor[formatProp] = f
+		}
+	}
+	if r.descriptor[schemaProp] != nil || !r.Tabular() {
+		return nil
+	}
+	dMap, err := r.dialectDescriptor(context.Background())
+	if err != nil {
+		return err
+	}
+	if !parseDialect(dMap).Header {
+		return fmt.Errorf("schema inference requires a header row. Resource:%s", r.name)
+	}
+	// GetTable already loads the headers when the resource has a dialect.
+	var opts []csv.CreationOpts
+	if dMap == nil {
+		opts = append(opts, csv.LoadHeaders())
+	}
+	tbl, err := r.GetTable(opts...)
+	if err != nil {
+		return err
+	}
+	sch, err := schema.Infer(tbl, schema.WithPriorityOrder(inferredTypes))
+	if err != nil {
+		return err
+	}
+	buf, err := json.Marshal(sch)
+	if err != nil {
+		return err
+	}
+	var schMap map[string]interface{}
+	if err := json.Unmarshal(buf, &schMap); err != nil {
+		return err
+	}
+	r.descriptor[schemaProp] = schMap
+	return nil
+}
+
+func inferFormat(p []string, mediaType interface{}) string {
+	if len(p) > 0 {
+		// The format of compressed files comes from the extension before the compression one.
+		if ext := path.Ext(strings.TrimSuffix(p[0], "."+gzipCompression)); ext != "" {
+			return strings.ToLower(strings.TrimPrefix(ext, "."))
+		}
+	}
+	if mStr, ok := mediaType.(string); ok {
+		return mediaTypeFormats[mStr]
+	}
+	return ""
+}
+
 // Cast resource contents.
 // The result argument must necessarily be the address for a slice. The slice
 // may be nil or previously allocated.
@@ -375,12 +1612,21 @@ func NewResourceWithDefaultRegistry(d map[string]interface{}) (*Resource, error)
 // NewResource creates a new Resource from the passed-in descriptor, if valid. The
 // passed-in validator.Registry will be the source of profiles used in the validation.
 func NewResource(d map[string]interface{}, registry validator.Registry) (*Resource, error) {
+	return newResource(context.Background(), d, "", nil, nil, registry)
+}
+
+// newResource creates a resource whose relative paths, including the schema path, are resolved
+// against basePath and opened with open, while remote paths are fetched with client. If nil, the
+// file system and defaultHTTPClient are used, respectively. Remote schemas are fetched using ctx.
+func newResource(ctx context.Context, d map[string]interface{}, basePath string, open openFunc, client *http.Client, registry validator.Registry) (*Resource, error) {
 	cpy, err := clone.Descriptor(d)
 	if err != nil {
 		return nil, err
 	}
+	r := Resource{basePath: basePath, open: open, client: client}
 	if schStr, ok := cpy[schemaProp].(string); ok {
-		cpy[schemaProp], err = loadSchema(schStr)
+		name, _ := cpy[nameProp].(string)
+		cpy[schemaProp], err = loadResourceSchema(ctx, schStr, name, basePath, open, r.httpClient())
 		if err != nil {
 			return nil, err
 		}
@@ -390,28 +1636,41 @@ func NewResource(d map[string]interface{}, registry validator.Registry) (*Resour
 	if !ok {
 		return nil, fmt.Errorf("profile property MUST be a string:\"%s\"", profilePropName)
 	}
-	if err := validator.Validate(cpy, profile, registry); err != nil {
+	// Checking the rules below before the profile, so their violations are reported as
+	// ValidationError values, which callers can tell apart using errors.Is.
+	name, err := parseName(cpy[nameProp])
+	if err != nil {
 		return nil, err
 	}
-	r := Resource{
-		descriptor: cpy,
-		name:       cpy[nameProp].(string),
-	}
-	pathI := cpy[pathProp]
-	if pathI != nil {
+	r.descriptor = cpy
+	r.name = name
+	pathI, dataI := cpy[pathProp], cpy[dataProp]
+	switch {
+	case pathI != nil && dataI != nil:
+		return nil, ValidationError{Field: "/" + dataProp, Rule: RulePathAndData, Message: "path and data properties MUST NOT be both provided"}
+	case pathI != nil:
 		p, err := parsePath(pathI, cpy)
 		if err != nil {
 			return nil, err
 		}
 		r.path = append([]string{}, p...)
-		return &r, nil
+	case dataI != nil:
+		data, err := parseData(dataI, cpy)
+		if err != nil {
+			return nil, err
+		}
+		r.data = data
+	default:
+		return nil, ValidationError{Field: "/" + pathProp, Rule: RulePathOrData, Message: "either path or data property MUST be provided"}
 	}
-	dataI := cpy[dataProp]
-	data, err := parseData(dataI, cpy)
-	if err != nil {
+	if dStr, ok := cpy[dialectProp].(string); ok {
+		if err := parseDialectPath(dStr, cpy); err != nil {
+			return nil, err
+		}
+	}
+	if err := validator.Validate(withoutDialectReference(cpy), profile, registry); err != nil {
 		return nil, err
 	}
-	r.data = data
 	return &r, nil
 }
 
@@ -436,19 +1695,71 @@ func fillResourceDescriptorWithDefaultValues(r map[string]interface{}) {
 	}
 }
 
+// Resource names must consist only of lowercase alphanumeric characters plus ".", "-" and "_".
+// https://specs.frictionlessdata.io/data-resource/#name
+var nameRegexp = regexp.MustCompile(`^[a-z0-9._-]+$`)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// SlugifyName turns the passed-in name into a valid resource name, lower casing it and
+// replacing each run of invalid characters (for instance, spaces) by "-". Leading and
+// trailing "-" are removed. For instance, "Station Data" becomes "station-data".
+func SlugifyName(name string) string {
+	return strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+func parseName(nameI interface{}) (string, error) {
+	if nameI == nil {
+		return "", ValidationError{Field: "/" + nameProp, Rule: RuleNameMissing, Message: "name property MUST be provided"}
+	}
+	name, ok := nameI.(string)
+	if !ok {
+		return "", ValidationError{Field: "/" + nameProp, Rule: RuleNameType, Message: fmt.Sprintf("name property MUST be a string:\"%v\"", nameI)}
+	}
+	if !nameRegexp.MatchString(name) {
+		return "", ValidationError{Field: "/" + nameProp, Rule: RuleNameFormat, Message: fmt.Sprintf("invalid resource name %q: it MUST consist only of lowercase alphanumeric characters plus \".\", \"-\" and \"_\"", name)}
+	}
+	return name, nil
+}
+
 func parseData(dataI interface{}, d map[string]interface{}) (interface{}, error) {
 	if dataI != nil {
 		switch dataI.(type) {
 		case string:
 			if d[formatProp] == nil && d[mediaTypeProp] == nil {
-				return nil, fmt.Errorf("format or mediatype properties MUST be provided for JSON data strings. Descriptor:%v", d)
+				return nil, ValidationError{Field: "/" + dataProp, Rule: RuleDataFormat, Message: fmt.Sprintf("format or mediatype properties MUST be provided for JSON data strings. Descriptor:%v", d)}
+			}
+			return dataI, nil
+		case []interface{}:
+			if d[profileProp] == tabularDataResourceProfile {
+				if err := checkRows(dataI.([]interface{})); err != nil {
+					err.Message = fmt.Sprintf("%s. Descriptor:%v", err.Message, d)
+					return nil, *err
+				}
 			}
 			return dataI, nil
-		case []interface{}, map[string]interface{}:
+		case map[string]interface{}:
 			return dataI, nil
 		}
 	}
-	return nil, fmt.Errorf("data property must be either a JSON array/object OR a JSON string. Descriptor:%v", d)
+	return nil, ValidationError{Field: "/" + dataProp, Rule: RuleDataType, Message: fmt.Sprintf("data property must be either a JSON array/object OR a JSON string. Descriptor:%v", d)}
+}
+
+// checkRows checks that inline tabular data rows are either objects (row-as-map) or arrays
+// (row-as-values). https://specs.frictionlessdata.io/tabular-data-resource/#json-tabular-data
+func checkRows(rows []interface{}) *ValidationError {
+	for i, row := range rows {
+		switch row.(type) {
+		case map[string]interface{}, []interface{}, []string:
+		default:
+			return &ValidationError{
+				Field:   fmt.Sprintf("/%s/%d", dataProp, i),
+				Rule:    RuleDataRows,
+				Message: fmt.Sprintf("inline tabular data rows MUST be JSON arrays or objects, row %d is %T", i, row),
+			}
+		}
+	}
+	return nil
 }
 
 func parsePath(pathI interface{}, d map[string]interface{}) ([]string, error) {
@@ -456,7 +1767,7 @@ func parsePath(pathI interface{}, d map[string]interface{}) ([]string, error) {
 	// Parse.
 	switch pathI.(type) {
 	default:
-		return nil, fmt.Errorf("path MUST be a string or an array of strings. Descriptor:%v", d)
+		return nil, ValidationError{Field: "/" + pathProp, Rule: RulePathType, Message: fmt.Sprintf("path MUST be a string or an array of strings. Descriptor:%v", d)}
 	case string:
 		if p, ok := pathI.(string); ok {
 			returned = append(returned, p)
@@ -464,10 +1775,10 @@ func parsePath(pathI interface{}, d map[string]interface{}) ([]string, error) {
 	case []string:
 		returned = append(returned, pathI.([]string)...)
 	case []interface{}:
-		for _, p := range pathI.([]interface{}) {
+		for i, p := range pathI.([]interface{}) {
 			pStr, ok := p.(string)
 			if !ok {
-				return nil, fmt.Errorf("path MUST be a string or an array of strings. Descriptor:%v", d)
+				return nil, ValidationError{Field: fmt.Sprintf("/%s/%d", pathProp, i), Rule: RulePathType, Message: fmt.Sprintf("path MUST be a string or an array of strings, element %d is %T. Descriptor:%v", i, p, d)}
 			}
 			returned = append(returned, pStr)
 		}
@@ -475,22 +1786,26 @@ func parsePath(pathI interface{}, d map[string]interface{}) ([]string, error) {
 	var lastType, currType pathType
 	// Validation.
 	for index, p := range returned {
+		field := "/" + pathProp
+		if _, ok := pathI.(string); !ok {
+			field = fmt.Sprintf("/%s/%d", pathProp, index)
+		}
 		// Check if it is a relative path.
 		u, err := url.Parse(p)
 		if err != nil || u.Scheme == "" {
-			if path.IsAbs(p) || strings.HasPrefix(path.Clean(p), "..") {
-				return nil, fmt.Errorf("absolute paths (/) and relative parent paths (../) MUST NOT be used. Descriptor:%v", d)
+			if _, ok := cleanRelativePath(p); !ok {
+				return nil, ValidationError{Field: field, Rule: RulePathLocation, Message: fmt.Sprintf("absolute paths (/) and relative parent paths (../) MUST NOT be used. Descriptor:%v", d)}
 			}
 			currType = relativePath
 		} else { // Check if it is a valid URL.
-			if u.Scheme != "http" && u.Scheme != "https" {
-				return nil, fmt.Errorf("URLs MUST be fully qualified. MUST be using either http or https scheme. Descriptor:%v", d)
+			if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return nil, ValidationError{Field: field, Rule: RulePathScheme, Message: fmt.Sprintf("URLs MUST be fully qualified. MUST be using either http or https scheme. Descriptor:%v", d)}
 			}
 			currType = urlPath
 		}
 		if index > 0 {
 			if currType != lastType {
-				return nil, fmt.Errorf("it is NOT permitted to mix fully qualified URLs and relative paths in a single resource. Descriptor:%v", d)
+				return nil, ValidationError{Field: field, Rule: RuleMixedPaths, Message: fmt.Sprintf("it is NOT permitted to mix fully qualified URLs and relative paths in a single resource. Descriptor:%v", d)}
 			}
 		}
 		lastType = currType
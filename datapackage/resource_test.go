@@ -1,13 +1,24 @@
 package datapackage
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/frictionlessdata/datapackage-go/validator"
 	"github.com/frictionlessdata/tableschema-go/csv"
@@ -15,6 +26,26 @@ import (
 	"github.com/matryer/is"
 )
 
+// newTestResource creates a resource using the in-memory registry, failing the test on errors. If
+// files is not nil, relative paths are read from it instead of the file system.
+func newTestResource(t *testing.T, d map[string]interface{}, files map[string]string) *Resource {
+	t.Helper()
+	r, err := NewResource(d, validator.MustInMemoryRegistry())
+	if err != nil {
+		t.Fatalf("want:nil got:%q", err)
+	}
+	if files != nil {
+		r.open = func(name string) (io.ReadCloser, error) {
+			contents, ok := files[name]
+			if !ok {
+				return nil, os.ErrNotExist
+			}
+			return ioutil.NopCloser(strings.NewReader(contents)), nil
+		}
+	}
+	return r
+}
+
 func ExampleResource_CastColumn() {
 	resStr := `
 	{
@@ -49,13 +80,22 @@ func TestNew(t *testing.T) {
 			{"AbsolutePath", map[string]interface{}{"name": "foo", "path": "/bar"}},
 			{"InvalidRelativePath", map[string]interface{}{"name": "foo", "path": "../bar"}},
 			{"InvalidSchemeURL", map[string]interface{}{"name": "foo", "path": "myscheme://bar"}},
+			{"BackslashParentPath", map[string]interface{}{"name": "foo", "path": "..\\bar"}},
+			{"BackslashInnerParentPath", map[string]interface{}{"name": "foo", "path": "data\\sub\\..\\..\\..\\bar"}},
+			{"MixedSeparatorsParentPath", map[string]interface{}{"name": "foo", "path": "data/sub\\../../..\\bar"}},
+			{"BackslashAbsolutePath", map[string]interface{}{"name": "foo", "path": "\\\\server\\share\\bar"}},
+			{"URLWithoutHost", map[string]interface{}{"name": "foo", "path": "http:\\\\bar"}},
 			{"MixedPaths", map[string]interface{}{"name": "foo", "path": []string{"https://bar", "bar"}}},
+			{"MixedPathsThirdElement", map[string]interface{}{"name": "foo", "path": []interface{}{"bar", "baz", "https://bar"}}},
+			{"MixedPathsSecondElement", map[string]interface{}{"name": "foo", "path": []interface{}{"https://bar", "bar", "baz"}}},
+			{"PathElementNotString", map[string]interface{}{"name": "foo", "path": []interface{}{"bar", 1}}},
 			{"PathAndData", map[string]interface{}{"name": "foo", "data": "foo", "path": "foo"}},
 			{"InvalidJSONStringData", map[string]interface{}{"name": "foo", "data": "invalidJSONObjectString"}},
 			{"InvalidJSONType", map[string]interface{}{"name": "foo", "data": 1}},
 			{"UpperCaseName", map[string]interface{}{"name": "UP", "path": "http://url.com"}},
 			{"NameInvalidChar", map[string]interface{}{"name": "u*p", "path": "http://url.com"}},
 			{"NameWithSpace", map[string]interface{}{"name": "u p", "path": "http://url.com"}},
+			{"NameWithSlash", map[string]interface{}{"name": "u/p", "path": "http://url.com"}},
 			{"NameIsNotString", map[string]interface{}{"name": 1, "path": "http://url.com"}},
 			{"SchemaAsInt", map[string]interface{}{"name": "name", "schema": 1, "path": "http://url.com"}},
 			{"SchemaInvalidPath", map[string]interface{}{"name": "name", "schema": "/bar", "path": "http://url.com"}},
@@ -80,6 +120,9 @@ func TestNew(t *testing.T) {
 		}{
 			{"NoPunctuation", map[string]interface{}{"name": "up", "path": "foo.csv"}, "up"},
 			{"WithPunctuation", map[string]interface{}{"name": "u.p_d.o.w.n", "path": "foo.csv"}, "u.p_d.o.w.n"},
+			{"WithHyphen", map[string]interface{}{"name": "my-resource", "path": "foo.csv"}, "my-resource"},
+			{"WithDigits", map[string]interface{}{"name": "res_v2", "path": "foo.csv"}, "res_v2"},
+			{"DotAndDigits", map[string]interface{}{"name": "table.01", "path": "foo.csv"}, "table.01"},
 		}
 		for _, d := range data {
 			t.Run(d.testDescription, func(t *testing.T) {
@@ -138,6 +181,16 @@ func TestNew(t *testing.T) {
 				map[string]interface{}{"name": "foo", "data": []interface{}{[]string{"A", "B"}, []string{"a", "b"}}},
 				[]interface{}{[]string{"A", "B"}, []string{"a", "b"}},
 			},
+			{
+				"TabularRows",
+				map[string]interface{}{
+					"name":    "foo",
+					"profile": "tabular-data-resource",
+					"schema":  map[string]interface{}{"fields": []interface{}{map[string]interface{}{"name": "a", "type": "integer"}}},
+					"data":    []interface{}{[]interface{}{"a"}, []interface{}{1}, map[string]interface{}{"a": 2}},
+				},
+				[]interface{}{[]interface{}{"a"}, []interface{}{1}, map[string]interface{}{"a": 2}},
+			},
 		}
 		for _, d := range data {
 			t.Run(d.testDescription, func(t *testing.T) {
@@ -209,6 +262,73 @@ func TestNew(t *testing.T) {
 	})
 }
 
+func TestParseData(t *testing.T) {
+	t.Run("TabularRowNotArrayOrObject", func(t *testing.T) {
+		is := is.New(t)
+		d := map[string]interface{}{"profile": "tabular-data-resource", "data": []interface{}{[]interface{}{"a"}, "b"}}
+		_, err := parseData(d["data"], d)
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), "row 1 is string"))
+	})
+	t.Run("NonTabularArray", func(t *testing.T) {
+		is := is.New(t)
+		d := map[string]interface{}{"profile": "data-resource", "data": []interface{}{1, "b"}}
+		data, err := parseData(d["data"], d)
+		is.NoErr(err)
+		is.Equal(data, []interface{}{1, "b"})
+	})
+}
+
+func TestParseName(t *testing.T) {
+	data := []struct {
+		desc  string
+		name  interface{}
+		valid bool
+	}{
+		{"Letters", "stations", true},
+		{"Digits", "temp1", true},
+		{"Hyphens", "stations-2020", true},
+		{"Punctuation", "u.p_d-o.w.n", true},
+		{"Uppercase", "Stations", false},
+		{"Spaces", "my stations", false},
+		{"Slash", "my/stations", false},
+		{"Empty", "", false},
+		{"NotString", 1, false},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			name, err := parseName(d.name)
+			if d.valid {
+				is.NoErr(err)
+				is.Equal(name, d.name)
+			} else {
+				is.True(err != nil)
+			}
+		})
+	}
+}
+
+func TestSlugifyName(t *testing.T) {
+	data := []struct {
+		desc string
+		name string
+		want string
+	}{
+		{"Valid", "stations-2020", "stations-2020"},
+		{"TitleCase", "StationData", "stationdata"},
+		{"Spaces", "Station Data 2020", "station-data-2020"},
+		{"InvalidChars", " (Station) Data! ", "station-data"},
+		{"Empty", "", ""},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(SlugifyName(d.name), d.want)
+		})
+	}
+}
+
 func TestResource_Descriptor(t *testing.T) {
 	is := is.New(t)
 	r, err := NewResource(r1, validator.MustInMemoryRegistry())
@@ -251,6 +371,402 @@ func TestResource_Tabular(t *testing.T) {
 	is.True(r2.Tabular())
 	r3 := NewUncheckedResource(map[string]interface{}{"path": []string{"boo.csv"}})
 	is.True(r3.Tabular())
+	r4 := NewUncheckedResource(map[string]interface{}{"mediatype": "text/tab-separated-values"})
+	is.True(r4.Tabular())
+	r5 := NewUncheckedResource(map[string]interface{}{"mediatype": "application/json", "path": []string{"boo.json"}})
+	is.True(!r5.Tabular())
+}
+
+func TestResource_IsTabular(t *testing.T) {
+	data := []struct {
+		desc    string
+		d       map[string]interface{}
+		tabular bool
+	}{
+		{"Schema", map[string]interface{}{"schema": map[string]interface{}{"fields": []interface{}{}}}, true},
+		{"CSVFormat", map[string]interface{}{"format": "csv"}, true},
+		{"TSVFormat", map[string]interface{}{"format": "TSV"}, true},
+		{"CSVMediaType", map[string]interface{}{"mediatype": "text/csv"}, true},
+		{"CSVMediaTypeWithCharset", map[string]interface{}{"mediatype": "text/csv; charset=utf-8"}, true},
+		{"Empty", map[string]interface{}{}, false},
+		{"NilSchema", map[string]interface{}{"schema": nil}, false},
+		{"SchemaReference", map[string]interface{}{"schema": "schema.json"}, false},
+		{"JSONFormat", map[string]interface{}{"format": "json"}, false},
+		{"XLSXFormat", map[string]interface{}{"format": "xlsx"}, false},
+		{"JSONMediaType", map[string]interface{}{"mediatype": "application/json"}, false},
+		{"TabularProfile", map[string]interface{}{"profile": "tabular-data-resource"}, false},
+		{"CSVPath", map[string]interface{}{"path": "data.csv"}, false},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			r := &Resource{descriptor: d.d}
+			is.Equal(r.IsTabular(), d.tabular)
+		})
+	}
+}
+
+func TestResource_Schema(t *testing.T) {
+	schStr := `{"fields": [{"name": "name", "type": "string"}]}`
+	schMap := map[string]interface{}{"fields": []interface{}{map[string]interface{}{"name": "name", "type": "string"}}}
+	t.Run("NoSchema", func(t *testing.T) {
+		is := is.New(t)
+		r := NewUncheckedResource(map[string]interface{}{"name": "foo", "path": []string{"foo.csv"}})
+		sch, err := r.Schema()
+		is.NoErr(err)
+		is.Equal(sch, nil)
+	})
+	t.Run("Inline", func(t *testing.T) {
+		is := is.New(t)
+		r := NewUncheckedResource(map[string]interface{}{"name": "foo", "path": []string{"foo.csv"}, "schema": schMap})
+		sch, err := r.Schema()
+		is.NoErr(err)
+		is.Equal(sch, schMap)
+
+		// Changes to the returned schema don't affect the resource.
+		sch["fields"] = nil
+		is.Equal(r.descriptor["schema"], schMap)
+	})
+	t.Run("RelativePath", func(t *testing.T) {
+		is := is.New(t)
+		dir, err := ioutil.TempDir("", "resourceSchema")
+		is.NoErr(err)
+		defer os.RemoveAll(dir)
+		is.NoErr(ioutil.WriteFile(filepath.Join(dir, "schema.json"), []byte(schStr), 0666))
+		r := NewUncheckedResource(map[string]interface{}{"name": "foo", "path": []string{"foo.csv"}, "schema": "schema.json"})
+		r.basePath = dir
+		sch, err := r.Schema()
+		is.NoErr(err)
+		is.Equal(sch, schMap)
+		s, err := r.GetSchema()
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Name, "name")
+	})
+	t.Run("RemoteCached", func(t *testing.T) {
+		is := is.New(t)
+		requests := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			is.Equal(r.URL.Path, "/pkg/schema.json")
+			fmt.Fprint(w, schStr)
+		}))
+		defer ts.Close()
+		r := NewUncheckedResource(map[string]interface{}{"name": "foo", "path": []string{"foo.csv"}, "schema": "schema.json"})
+		r.basePath = ts.URL + "/pkg"
+		for i := 0; i < 2; i++ {
+			sch, err := r.Schema()
+			is.NoErr(err)
+			is.Equal(sch, schMap)
+		}
+		is.Equal(requests, 1)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		data := []struct {
+			desc   string
+			schema interface{}
+		}{
+			{"NotFound", "nonexisting.json"},
+			{"InvalidType", 10.0},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				r := NewUncheckedResource(map[string]interface{}{"name": "foo", "path": []string{"foo.csv"}, "schema": d.schema})
+				_, err := r.Schema()
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestResource_Validate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		data := []struct {
+			desc string
+			d    map[string]interface{}
+		}{
+			{"Path", map[string]interface{}{"name": "foo", "path": "foo.csv"}},
+			{"MultipartURL", map[string]interface{}{"name": "foo", "path": []interface{}{"http://a.com/1.csv", "https://a.com/2.csv"}}},
+			{"Data", map[string]interface{}{"name": "foo", "data": []interface{}{[]interface{}{"a"}}}},
+			{"Compression", map[string]interface{}{"name": "foo", "path": "foo.csv", "compression": "gz"}},
+			{"Schema", map[string]interface{}{"name": "foo", "path": "foo.csv", "schema": map[string]interface{}{"fields": []interface{}{map[string]interface{}{"name": "a"}}}}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				r := &Resource{descriptor: d.d}
+				is.Equal(len(r.Validate(validator.InMemoryLoader())), 0)
+			})
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			d     map[string]interface{}
+			field string
+			rule  string
+		}{
+			{"NameMissing", map[string]interface{}{"path": "foo.csv"}, "/name", RuleNameMissing},
+			{"NameNotString", map[string]interface{}{"name": 1.0, "path": "foo.csv"}, "/name", RuleProfile},
+			{"NameInvalidChars", map[string]interface{}{"name": "Foo Bar", "path": "foo.csv"}, "/name", RuleProfile},
+			{"NameInvalidSlash", map[string]interface{}{"name": "foo/bar", "path": "foo.csv"}, "/name", RuleNameFormat},
+			{"NoPathOrData", map[string]interface{}{"name": "foo"}, "/path", RulePathOrData},
+			{"PathAndData", map[string]interface{}{"name": "foo", "path": "foo.csv", "data": []interface{}{}}, "/data", RulePathAndData},
+			{"PathNotString", map[string]interface{}{"name": "foo", "path": 1.0}, "/path", RuleProfile},
+			{"PathElementNotString", map[string]interface{}{"name": "foo", "path": []interface{}{"foo.csv", 1.0}}, "/path/1", RuleProfile},
+			{"PathAbsolute", map[string]interface{}{"name": "foo", "path": "/foo.csv"}, "/path", RulePathLocation},
+			{"PathParent", map[string]interface{}{"name": "foo", "path": []interface{}{"foo.csv", "../foo.csv"}}, "/path/1", RulePathLocation},
+			{"PathInvalidScheme", map[string]interface{}{"name": "foo", "path": "ftp://a.com/foo.csv"}, "/path", RulePathScheme},
+			{"PathMixed", map[string]interface{}{"name": "foo", "path": []interface{}{"http://a.com/foo.csv", "foo.csv"}}, "/path/1", RuleMixedPaths},
+			{"DataStringWithoutFormat", map[string]interface{}{"name": "foo", "data": "a,b"}, "/data", RuleDataFormat},
+			{"DataNumber", map[string]interface{}{"name": "foo", "data": 1.0}, "/data", RuleDataType},
+			{"DataTabularRow", map[string]interface{}{"name": "foo", "profile": "tabular-data-resource", "data": []interface{}{[]interface{}{"a"}, "b"}, "schema": map[string]interface{}{"fields": []interface{}{map[string]interface{}{"name": "a"}}}}, "/data/1", RuleDataRows},
+			{"Compression", map[string]interface{}{"name": "foo", "path": "foo.csv", "compression": "zip"}, "/compression", RuleCompression},
+			{"SchemaNotFound", map[string]interface{}{"name": "foo", "path": "foo.csv", "schema": "nonexisting.json"}, "/schema", RuleSchemaLoad},
+			{"SchemaFieldWithoutName", map[string]interface{}{"name": "foo", "path": "foo.csv", "schema": map[string]interface{}{"fields": []interface{}{map[string]interface{}{"type": "string"}}}}, "/schema", RuleSchemaFormat},
+			{"SchemaInvalidPrimaryKey", map[string]interface{}{"name": "foo", "path": "foo.csv", "schema": map[string]interface{}{"fields": []interface{}{map[string]interface{}{"name": "a"}}, "primaryKey": "b"}}, "/schema", RuleSchemaFormat},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				r := &Resource{descriptor: d.d, name: "foo"}
+				errs := r.Validate(validator.InMemoryLoader())
+				found := false
+				for _, err := range errs {
+					vErr, ok := err.(ValidationError)
+					is.True(ok) // All problems must be validation errors.
+					if vErr.Field == d.field && vErr.Rule == d.rule {
+						found = true
+					}
+				}
+				is.True(found) // Expected an error pointing to the invalid property.
+			})
+		}
+	})
+	t.Run("NewResource", func(t *testing.T) {
+		is := is.New(t)
+		_, err := NewResource(map[string]interface{}{"name": "foo", "path": []interface{}{"http://a.com/foo.csv", "foo.csv"}}, validator.MustInMemoryRegistry())
+		vErr, ok := err.(ValidationError)
+		is.True(ok)
+		is.Equal(vErr.Field, "/path/1")
+		is.Equal(vErr.Rule, RuleMixedPaths)
+		is.True(strings.HasPrefix(vErr.Error(), "/path/1: "))
+	})
+	t.Run("ErrorKinds", func(t *testing.T) {
+		data := []struct {
+			desc string
+			d    map[string]interface{}
+			want error
+		}{
+			{"MissingName", map[string]interface{}{"path": "foo.csv"}, ErrMissingName},
+			{"InvalidName", map[string]interface{}{"name": "Foo", "path": "foo.csv"}, ErrInvalidName},
+			{"PathAndData", map[string]interface{}{"name": "foo", "path": "foo.csv", "data": "a,b", "format": "csv"}, ErrPathAndData},
+			{"MissingPathOrData", map[string]interface{}{"name": "foo"}, ErrMissingPathOrData},
+			{"InvalidPath", map[string]interface{}{"name": "foo", "path": "../foo.csv"}, ErrInvalidPath},
+			{"InvalidPathType", map[string]interface{}{"name": "foo", "path": []interface{}{"foo.csv", 1}}, ErrInvalidPath},
+			{"InvalidData", map[string]interface{}{"name": "foo", "data": 1}, ErrInvalidData},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := NewResource(d.d, validator.MustInMemoryRegistry())
+				is.True(errors.Is(err, d.want))
+				var vErr ValidationError
+				is.True(errors.As(err, &vErr))
+				is.True(vErr.Rule != "")
+			})
+		}
+		// Errors wrapping validation errors keep their kind.
+		is := is.New(t)
+		pkg, err := New(map[string]interface{}{"resources": []interface{}{r1}}, ".", validator.InMemoryLoader())
+		is.NoErr(err)
+		err = pkg.AddResources([]map[string]interface{}{{"name": "foo", "path": "/foo.csv"}})
+		is.True(err != nil)
+		is.True(errors.Is(err.(MultiError)[0], ErrInvalidPath))
+	})
+	t.Run("AllProblems", func(t *testing.T) {
+		is := is.New(t)
+		r := &Resource{descriptor: map[string]interface{}{"name": "Foo", "path": "../foo.csv"}}
+		is.True(len(r.Validate(validator.InMemoryLoader())) >= 2)
+	})
+}
+
+func TestResource_Clone(t *testing.T) {
+	t.Run("Path", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": []interface{}{"foo.csv", "bar.csv"}}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		r.basePath = "data"
+		c, err := r.Clone()
+		is.NoErr(err)
+		is.Equal(c.Descriptor(), r.Descriptor())
+		is.Equal(c.path, r.path)
+		is.Equal(c.basePath, "data")
+
+		// Changing the clone must not change the original resource.
+		c.descriptor["title"] = "bar"
+		c.descriptor["path"].([]interface{})[0] = "baz.csv"
+		c.path[0] = "baz.csv"
+		is.Equal(r.descriptor["path"], []interface{}{"foo.csv", "bar.csv"})
+		is.Equal(r.path, []string{"foo.csv", "bar.csv"})
+		_, ok := r.descriptor["title"]
+		is.True(!ok)
+	})
+	t.Run("Data", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "data": []interface{}{map[string]interface{}{"a": "1"}}}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		c, err := r.Clone()
+		is.NoErr(err)
+		c.data.([]interface{})[0].(map[string]interface{})["a"] = "2"
+		is.Equal(r.data, []interface{}{map[string]interface{}{"a": "1"}})
+		is.Equal(c.descriptor["data"], []interface{}{map[string]interface{}{"a": "2"}})
+	})
+}
+
+func TestResource_FullPaths(t *testing.T) {
+	data := []struct {
+		desc     string
+		basePath string
+		path     []string
+		want     []string
+	}{
+		{"LocalBase", "data", []string{"foo.csv", "sub/bar.csv"}, []string{filepath.Join("data", "foo.csv"), filepath.Join("data", "sub", "bar.csv")}},
+		{"NoBase", "", []string{"foo.csv"}, []string{"foo.csv"}},
+		{"URLBase", "http://example.com/pkg", []string{"foo.csv", "sub/./bar.csv"}, []string{"http://example.com/pkg/foo.csv", "http://example.com/pkg/sub/bar.csv"}},
+		{"URLPath", "data", []string{"https://example.com/foo.csv"}, []string{"https://example.com/foo.csv"}},
+		{"InnerParent", "data", []string{"sub/../foo.csv"}, []string{filepath.Join("data", "foo.csv")}},
+		{"Backslashes", "data", []string{"sub\\bar.csv"}, []string{filepath.Join("data", "sub", "bar.csv")}},
+		{"DotsInName", "data", []string{"..foo.csv"}, []string{filepath.Join("data", "..foo.csv")}},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			r := NewUncheckedResource(map[string]interface{}{"name": "foo", "path": d.path})
+			r.basePath = d.basePath
+			got, err := r.FullPaths()
+			is.NoErr(err)
+			is.Equal(got, d.want)
+		})
+	}
+	t.Run("Traversal", func(t *testing.T) {
+		data := []struct {
+			desc     string
+			basePath string
+			path     string
+		}{
+			{"LocalParent", "data", "../foo.csv"},
+			{"LocalInnerParent", "data", "sub/../../foo.csv"},
+			{"LocalAbsolute", "data", "/etc/passwd"},
+			{"URLParent", "http://example.com/pkg", "../foo.csv"},
+			{"URLAbsolute", "http://example.com/pkg", "/foo.csv"},
+			{"LocalBackslashParent", "data", "..\\foo.csv"},
+			{"LocalMixedSeparators", "data", "sub\\../..\\foo.csv"},
+			{"URLBackslashParent", "http://example.com/pkg", "sub\\..\\..\\foo.csv"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				r := NewUncheckedResource(map[string]interface{}{"name": "foo", "path": []string{d.path}})
+				r.basePath = d.basePath
+				_, err := r.FullPaths()
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestResource_Format(t *testing.T) {
+	data := []struct {
+		desc string
+		d    map[string]interface{}
+		want string
+	}{
+		{"Explicit", map[string]interface{}{"format": "tsv", "path": []string{"foo.csv"}}, "tsv"},
+		{"CSV", map[string]interface{}{"path": []string{"foo.csv"}}, "csv"},
+		{"JSON", map[string]interface{}{"path": []string{"dir/foo.json"}}, "json"},
+		{"UpperCaseXLSX", map[string]interface{}{"path": []string{"foo.XLSX"}}, "xlsx"},
+		{"FirstPath", map[string]interface{}{"path": []string{"foo.csv", "bar.json"}}, "csv"},
+		{"MediaType", map[string]interface{}{"path": []string{"foo"}, "mediatype": "text/csv"}, "csv"},
+		{"Unknown", map[string]interface{}{"path": []string{"foo"}}, ""},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(NewUncheckedResource(d.d).Format(), d.want)
+		})
+	}
+}
+
+func TestResource_MediaType(t *testing.T) {
+	data := []struct {
+		desc string
+		d    map[string]interface{}
+		want string
+	}{
+		{"Explicit", map[string]interface{}{"mediatype": "application/ld+json", "path": []string{"foo.json"}}, "application/ld+json"},
+		{"Format", map[string]interface{}{"format": "CSV", "path": []string{"foo"}}, "text/csv"},
+		{"JSONExtension", map[string]interface{}{"path": []string{"foo.json"}}, "application/json"},
+		{"XLSXExtension", map[string]interface{}{"path": []string{"foo.xlsx"}}, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+		{"UnknownFormat", map[string]interface{}{"path": []string{"foo.bin"}}, "application/octet-stream"},
+		{"NoFormat", map[string]interface{}{"path": []string{"foo"}}, "application/octet-stream"},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(NewUncheckedResource(d.d).MediaType(), d.want)
+		})
+	}
+}
+
+func TestResource_Encoding(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(NewUncheckedResource(map[string]interface{}{"name": "foo", "path": []string{"foo.csv"}}).Encoding(), "utf-8")
+		is.Equal((&Resource{descriptor: map[string]interface{}{"encoding": 1}}).Encoding(), "utf-8")
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": "foo.csv"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		is.Equal(r.Encoding(), "utf-8")
+	})
+	t.Run("SetEncoding", func(t *testing.T) {
+		is := is.New(t)
+		r := NewUncheckedResource(map[string]interface{}{"name": "foo", "path": []string{"foo.csv"}})
+		is.NoErr(r.SetEncoding("ISO-8859-1"))
+		is.Equal(r.Encoding(), "ISO-8859-1")
+		buf, err := json.Marshal(r)
+		is.NoErr(err)
+		is.Equal(string(buf), `{"encoding":"ISO-8859-1","name":"foo","path":["foo.csv"]}`)
+		is.NoErr(r.SetEncoding("ANSI_X3.4-1968"))
+	})
+	t.Run("InvalidEncoding", func(t *testing.T) {
+		for _, enc := range []string{"", "utf 8", "utf-8\n", strings.Repeat("a", 41)} {
+			is := is.New(t)
+			r := NewUncheckedResource(map[string]interface{}{"name": "foo", "path": []string{"foo.csv"}, "encoding": "utf-8"})
+			is.True(r.SetEncoding(enc) != nil)
+			is.Equal(r.Encoding(), "utf-8")
+		}
+	})
+}
+
+func TestResource_SetFormat(t *testing.T) {
+	is := is.New(t)
+	r := NewUncheckedResource(map[string]interface{}{"name": "foo", "path": []string{"foo.csv"}})
+	r.SetFormat("tsv")
+	r.SetMediaType("text/tab-separated-values")
+	is.Equal(r.Format(), "tsv")
+	is.Equal(r.MediaType(), "text/tab-separated-values")
+	buf, err := json.Marshal(r)
+	is.NoErr(err)
+	is.Equal(string(buf), `{"format":"tsv","mediatype":"text/tab-separated-values","name":"foo","path":["foo.csv"]}`)
+
+	// Empty values remove the properties.
+	r.SetFormat("")
+	r.SetMediaType("")
+	is.Equal(r.Format(), "csv")
+	is.Equal(r.MediaType(), "text/csv")
+	buf, err = json.Marshal(r)
+	is.NoErr(err)
+	is.Equal(string(buf), `{"name":"foo","path":["foo.csv"]}`)
 }
 
 func TestResource_ReadAll(t *testing.T) {
@@ -353,6 +869,74 @@ func TestResource_ReadAll(t *testing.T) {
 	})
 }
 
+func TestResource_ReadAllMultipart(t *testing.T) {
+	files := map[string]string{
+		"part1.csv":   "name,age\nfoo,42\n",
+		"part2.csv":   "name,age\nbar,84",
+		"part3.csv":   "name,age\nbaz,21\nqux,12\n",
+		"nohead.csv":  "quux,7",
+		"invalid.csv": "name,age,city\nbar,84,london",
+		"rows1.csv":   "foo,42\nbar,84\n",
+		"rows2.csv":   "foo,42\nbaz,21",
+	}
+	t.Run("TwoParts", func(t *testing.T) {
+		is := is.New(t)
+		contents, err := newTestResource(t, map[string]interface{}{"name": "foo", "format": "csv", "path": []interface{}{"part1.csv", "part2.csv"}}, files).ReadAll(csv.LoadHeaders())
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"foo", "42"}, {"bar", "84"}})
+	})
+	t.Run("ThreeParts", func(t *testing.T) {
+		is := is.New(t)
+		contents, err := newTestResource(t, map[string]interface{}{"name": "foo", "format": "csv", "path": []interface{}{"part1.csv", "part2.csv", "part3.csv"}}, files).ReadAll(csv.LoadHeaders())
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"foo", "42"}, {"bar", "84"}, {"baz", "21"}, {"qux", "12"}})
+	})
+	t.Run("NoHeaderInFollowingPart", func(t *testing.T) {
+		is := is.New(t)
+		contents, err := newTestResource(t, map[string]interface{}{"name": "foo", "format": "csv", "path": []interface{}{"part1.csv", "nohead.csv"}}, files).ReadAll(csv.LoadHeaders())
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"foo", "42"}, {"quux", "7"}})
+	})
+	t.Run("NoHeaderDialect", func(t *testing.T) {
+		is := is.New(t)
+		r := newTestResource(t, map[string]interface{}{"name": "foo", "format": "csv", "path": []interface{}{"rows1.csv", "rows2.csv"}}, files)
+		r.descriptor["dialect"] = map[string]interface{}{"header": false}
+		contents, err := r.ReadAll()
+		is.NoErr(err)
+		// Chunks starting with the same row must not lose it, as there is no header row.
+		is.Equal(contents, [][]string{{"foo", "42"}, {"bar", "84"}, {"foo", "42"}, {"baz", "21"}})
+	})
+	t.Run("MismatchedHeader", func(t *testing.T) {
+		// Both the tabular and the streaming reads check the chunks.
+		reads := map[string]func(r *Resource) error{
+			"ReadAll": func(r *Resource) error {
+				_, err := r.ReadAll()
+				return err
+			},
+			"ReadCloser": func(r *Resource) error {
+				rc, err := r.ReadCloser()
+				if err != nil {
+					return err
+				}
+				defer rc.Close()
+				_, err = ioutil.ReadAll(rc)
+				return err
+			},
+		}
+		for name, read := range reads {
+			t.Run(name, func(t *testing.T) {
+				err := read(newTestResource(t, map[string]interface{}{"name": "foo", "format": "csv", "path": []interface{}{"part1.csv", "part2.csv", "invalid.csv"}}, files))
+				if err == nil {
+					t.Fatalf("want:err got:nil")
+				}
+				if !strings.Contains(err.Error(), "invalid.csv has 3 columns, want 2") {
+					t.Fatalf("error must name the offending chunk, got:%q", err)
+				}
+			})
+		}
+	})
+}
+
 func TestResource_Iter(t *testing.T) {
 	is := is.New(t)
 	resStr := `
@@ -394,12 +978,109 @@ func TestResource_GetSchema(t *testing.T) {
 		is.Equal(row.Age, 32)
 	})
 	t.Run("NoSchema", func(t *testing.T) {
+		is := is.New(t)
 		res := NewUncheckedResource(map[string]interface{}{})
 		_, err := res.GetSchema()
-		if err == nil {
-			t.Fatal("want:err got:nil")
+		is.True(errors.Is(err, ErrNoSchema))
+	})
+	t.Run("LocalFile", func(t *testing.T) {
+		is := is.New(t)
+		dir, err := ioutil.TempDir("", "datapackage_getschema")
+		is.NoErr(err)
+		defer os.RemoveAll(dir)
+		is.NoErr(ioutil.WriteFile(filepath.Join(dir, "schema.json"), []byte(`{"fields": [{"name": "Age", "type": "integer"}]}`), 0666))
+		res := &Resource{descriptor: map[string]interface{}{"name": "foo", "path": "foo.csv", "schema": "schema.json"}, basePath: dir}
+		sch, err := res.GetSchema()
+		is.NoErr(err)
+		is.Equal(sch.Fields[0].Name, "Age")
+	})
+	t.Run("Remote", func(t *testing.T) {
+		is := is.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"fields": [{"name": "Age", "type": "integer"}]}`)
+		}))
+		defer ts.Close()
+		res := &Resource{descriptor: map[string]interface{}{"name": "foo", "path": "foo.csv", "schema": ts.URL + "/schema.json"}}
+		sch, err := res.GetSchema()
+		is.NoErr(err)
+		is.Equal(sch.Fields[0].Name, "Age")
+	})
+	t.Run("LoadFailure", func(t *testing.T) {
+		is := is.New(t)
+		ts := httptest.NewServer(http.NotFoundHandler())
+		defer ts.Close()
+		for _, d := range []struct{ basePath, schema, location string }{
+			{"testdata", "missing.json", filepath.Join("testdata", "missing.json")},
+			{ts.URL, "schema.json", ts.URL + "/schema.json"},
+		} {
+			res := &Resource{descriptor: map[string]interface{}{"name": "foo", "path": "foo.csv", "schema": d.schema}, basePath: d.basePath}
+			_, err := res.GetSchema()
+			is.True(err != nil)
+			is.True(!errors.Is(err, ErrNoSchema))
+			is.True(strings.Contains(err.Error(), d.location))
+		}
+	})
+}
+
+func TestResource_Infer(t *testing.T) {
+	t.Run("MixedColumns", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{
+			"name":   "mixed",
+			"format": "csv",
+			"data":   "id,price,active,day,city\n1,1.5,true,2017-01-02,london\n2,2.25,false,2017-02-03,paris",
+		}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		is.NoErr(r.Infer())
+		sch, err := r.GetSchema()
+		is.NoErr(err)
+		var got [][]string
+		for _, f := range sch.Fields {
+			got = append(got, []string{f.Name, string(f.Type)})
+		}
+		is.Equal(got, [][]string{
+			{"id", "integer"},
+			{"price", "number"},
+			{"active", "boolean"},
+			{"day", "date"},
+			{"city", "string"},
+		})
+	})
+	t.Run("Format", func(t *testing.T) {
+		data := []struct {
+			desc string
+			d    map[string]interface{}
+			want interface{}
+		}{
+			{"Extension", map[string]interface{}{"name": "foo", "path": "foo.JSON"}, "json"},
+			{"MediaType", map[string]interface{}{"name": "foo", "path": "foo", "mediatype": "text/csv", "schema": map[string]interface{}{}}, "csv"},
+			{"Unknown", map[string]interface{}{"name": "foo", "path": "foo"}, nil},
+			{"Explicit", map[string]interface{}{"name": "foo", "path": "foo.txt", "format": "csv", "schema": map[string]interface{}{}}, "csv"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				r, err := NewResource(d.d, validator.MustInMemoryRegistry())
+				is.NoErr(err)
+				is.NoErr(r.Infer())
+				is.Equal(r.descriptor["format"], d.want)
+			})
 		}
 	})
+	t.Run("ExplicitSchema", func(t *testing.T) {
+		is := is.New(t)
+		sch := map[string]interface{}{"fields": []interface{}{map[string]interface{}{"name": "id", "type": "string"}}}
+		r, err := NewResource(map[string]interface{}{"name": "foo", "format": "csv", "data": "id\n1", "schema": sch}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		is.NoErr(r.Infer())
+		is.Equal(r.descriptor["schema"], sch)
+	})
+	t.Run("NoHeader", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "format": "csv", "data": "1", "dialect": map[string]interface{}{"header": false}}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		is.True(r.Infer() != nil)
+	})
 }
 
 func TestResource_Cast(t *testing.T) {
@@ -437,20 +1118,211 @@ func TestResource_Cast(t *testing.T) {
 	})
 }
 
-func TestResource_RawRead(t *testing.T) {
-	t.Run("Remote", func(t *testing.T) {
+func TestResource_Context(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "name\nfoo\n")
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer ts.Close()
+	res, err := NewResource(map[string]interface{}{"name": "names", "path": ts.URL + "/data.csv", "format": "csv"}, validator.MustInMemoryRegistry())
+	if err != nil {
+		t.Fatalf("want:nil got:%q", err)
+	}
+	t.Run("ReadAllContext", func(t *testing.T) {
 		is := is.New(t)
-		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			fmt.Fprint(w, "1234")
-		}))
-		defer ts.Close()
-		resStr := fmt.Sprintf(`
-		{
-			"name":    "ids",
-			"path":    "%s/id1",
-			"profile": "data-resource"
-		}`, ts.URL)
-		res, err := NewResourceFromString(resStr, validator.MustInMemoryRegistry())
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := res.ReadAllContext(ctx)
+		is.True(errors.Is(err, context.DeadlineExceeded))
+		is.True(strings.Contains(err.Error(), "names"))
+		is.True(time.Since(start) < 2*time.Second)
+	})
+	t.Run("IterContext", func(t *testing.T) {
+		is := is.New(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		iter, err := res.IterContext(ctx)
+		is.NoErr(err)
+		defer iter.Close()
+		for iter.Next() {
+		}
+		is.True(errors.Is(iter.Err(), context.DeadlineExceeded))
+		is.True(time.Since(start) < 2*time.Second)
+	})
+	t.Run("RawReadContext", func(t *testing.T) {
+		is := is.New(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		rc, err := res.RawReadContext(ctx)
+		is.NoErr(err)
+		defer rc.Close()
+		// Cancelling in the middle of the download.
+		time.AfterFunc(100*time.Millisecond, cancel)
+		start := time.Now()
+		_, err = ioutil.ReadAll(rc)
+		is.True(errors.Is(err, context.Canceled))
+		is.True(time.Since(start) < 2*time.Second)
+	})
+}
+
+func TestResource_BOM(t *testing.T) {
+	dir, err := ioutil.TempDir("", "resource_bom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "bom1.csv"), []byte("\xEF\xBB\xBFname,year\nfoo,2017\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "bom2.csv"), []byte("\xEF\xBB\xBFname,year\nbar,2018\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	t.Run("Iter", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": "bom1.csv"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		r.basePath = dir
+		tbl, err := r.GetTable(csv.LoadHeaders())
+		is.NoErr(err)
+		is.Equal(tbl.Headers(), []string{"name", "year"})
+		iter, err := r.Iter(csv.LoadHeaders())
+		is.NoErr(err)
+		defer iter.Close()
+		is.True(iter.Next())
+		is.Equal(iter.Row(), []string{"foo", "2017"})
+	})
+	t.Run("Multipart", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": []interface{}{"bom1.csv", "bom2.csv"}}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		r.basePath = dir
+		contents, err := r.ReadAll()
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"name", "year"}, {"foo", "2017"}, {"bar", "2018"}})
+	})
+	t.Run("ReadRows", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": "bom1.csv", "dialect": map[string]interface{}{"header": false}}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		r.basePath = dir
+		rows, err := r.ReadRows()
+		is.NoErr(err)
+		is.Equal(rows[0], []string{"name", "year"})
+	})
+}
+
+func TestResource_Gzip(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write([]byte("name\nfoo\nbar"))
+	gz.Close()
+	open := func(contents []byte) openFunc {
+		return func(string) (io.ReadCloser, error) { return ioutil.NopCloser(bytes.NewReader(contents)), nil }
+	}
+	data := []struct {
+		desc string
+		d    map[string]interface{}
+	}{
+		{"Extension", map[string]interface{}{"name": "foo", "path": "data.csv.gz"}},
+		{"CompressionProperty", map[string]interface{}{"name": "foo", "path": "data", "format": "csv", "compression": "gz"}},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			r, err := NewResource(d.d, validator.MustInMemoryRegistry())
+			is.NoErr(err)
+			r.open = open(buf.Bytes())
+			is.True(r.Tabular())
+			is.Equal(r.Format(), "csv")
+			contents, err := r.ReadAll()
+			is.NoErr(err)
+			is.Equal(contents, [][]string{{"name"}, {"foo"}, {"bar"}})
+			rows, err := r.ReadRows()
+			is.NoErr(err)
+			is.Equal(rows, [][]string{{"foo"}, {"bar"}})
+
+			rc, err := r.RawRead()
+			is.NoErr(err)
+			defer rc.Close()
+			raw, err := ioutil.ReadAll(rc)
+			is.NoErr(err)
+			is.Equal(string(raw), "name\nfoo\nbar")
+		})
+	}
+	t.Run("NotCompressed", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": "data.csv.gz", "compression": "no"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		r.open = open([]byte("name\nfoo"))
+		contents, err := r.ReadAll()
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"name"}, {"foo"}})
+	})
+	t.Run("InvalidGzip", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": "data.csv.gz"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		r.open = open([]byte("name\nfoo"))
+		_, err = r.RawRead()
+		is.True(err != nil)
+	})
+	t.Run("UnsupportedCompression", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": "data.csv", "compression": "bz2"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		r.open = open(buf.Bytes())
+		_, err = r.ReadAll()
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), "unsupported compression"))
+		_, err = r.RawRead()
+		is.True(err != nil)
+	})
+	t.Run("Local", func(t *testing.T) {
+		is := is.New(t)
+		dir, err := ioutil.TempDir("", "resource_gzip")
+		is.NoErr(err)
+		defer os.RemoveAll(dir)
+		is.NoErr(ioutil.WriteFile(filepath.Join(dir, "data.csv.gz"), buf.Bytes(), 0666))
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": "data.csv.gz"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		r.basePath = dir
+		contents, err := r.ReadAll()
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"name"}, {"foo"}, {"bar"}})
+	})
+	t.Run("Remote", func(t *testing.T) {
+		is := is.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write(buf.Bytes())
+		}))
+		defer ts.Close()
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": ts.URL + "/data", "format": "csv", "compression": "gz"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		contents, err := r.ReadAll()
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"name"}, {"foo"}, {"bar"}})
+	})
+}
+
+func TestResource_RawRead(t *testing.T) {
+	t.Run("Remote", func(t *testing.T) {
+		is := is.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "1234")
+		}))
+		defer ts.Close()
+		resStr := fmt.Sprintf(`
+		{
+			"name":    "ids",
+			"path":    "%s/id1",
+			"profile": "data-resource"
+		}`, ts.URL)
+		res, err := NewResourceFromString(resStr, validator.MustInMemoryRegistry())
 		is.NoErr(err)
 		rc, err := res.RawRead()
 		is.NoErr(err)
@@ -474,6 +1346,599 @@ func TestResource_RawRead(t *testing.T) {
 		is.NoErr(err)
 		is.Equal(string(contents), "{\"foo\":\"1234\"}")
 	})
+	t.Run("InlineJSON", func(t *testing.T) {
+		is := is.New(t)
+		resStr := `
+			{
+				"name": "ids", "data": [{"foo":"1234"}], "profile":"data-resource"
+			}`
+		res, err := NewResourceFromString(resStr, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		rc, err := res.RawRead()
+		is.NoErr(err)
+		defer rc.Close()
+		contents, err := ioutil.ReadAll(rc)
+		is.NoErr(err)
+		is.Equal(string(contents), `[{"foo":"1234"}]`)
+	})
+	t.Run("Local", func(t *testing.T) {
+		is := is.New(t)
+		dir, err := ioutil.TempDir("", "resource_rawread")
+		is.NoErr(err)
+		defer os.RemoveAll(dir)
+		is.NoErr(ioutil.WriteFile(filepath.Join(dir, "id1.bin"), []byte{0, 1, 2, 3}, 0666))
+
+		res, err := NewResource(map[string]interface{}{"name": "ids", "path": "id1.bin"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		res.basePath = dir
+		// Every call must return a new reader.
+		for i := 0; i < 2; i++ {
+			rc, err := res.RawRead()
+			is.NoErr(err)
+			contents, err := ioutil.ReadAll(rc)
+			is.NoErr(err)
+			is.NoErr(rc.Close())
+			is.Equal(contents, []byte{0, 1, 2, 3})
+		}
+	})
+}
+
+func TestResource_Bytes(t *testing.T) {
+	t.Run("Local", func(t *testing.T) {
+		is := is.New(t)
+		dir, err := ioutil.TempDir("", "resource_bytes")
+		is.NoErr(err)
+		defer os.RemoveAll(dir)
+		is.NoErr(ioutil.WriteFile(filepath.Join(dir, "ids.txt"), []byte("1234"), 0666))
+
+		res, err := NewResource(map[string]interface{}{"name": "ids", "path": "ids.txt"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		contents, err := res.Bytes(dir)
+		is.NoErr(err)
+		is.Equal(string(contents), "1234")
+
+		// Without an override, the resource base path is used.
+		res.basePath = dir
+		contents, err = res.Bytes("")
+		is.NoErr(err)
+		is.Equal(string(contents), "1234")
+	})
+	t.Run("InlineJSON", func(t *testing.T) {
+		is := is.New(t)
+		res, err := NewResourceFromString(`{"name": "ids", "data": [{"foo":"1234"}], "profile":"data-resource"}`, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		contents, err := res.Bytes("")
+		is.NoErr(err)
+		is.Equal(string(contents), `[{"foo":"1234"}]`)
+	})
+	t.Run("Remote", func(t *testing.T) {
+		is := is.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "1234")
+		}))
+		defer ts.Close()
+		res, err := NewResource(map[string]interface{}{"name": "ids", "path": ts.URL + "/ids.txt"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		contents, err := res.Bytes("/does/not/matter")
+		is.NoErr(err)
+		is.Equal(string(contents), "1234")
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		is := is.New(t)
+		dir, err := ioutil.TempDir("", "resource_bytes")
+		is.NoErr(err)
+		defer os.RemoveAll(dir)
+		res, err := NewResource(map[string]interface{}{"name": "ids", "path": "ids.txt"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		_, err = res.Bytes(dir)
+		is.True(err != nil)
+	})
+}
+
+func TestResource_ReadCloser(t *testing.T) {
+	files := map[string]string{
+		"part1.csv": "name\nfoo\n",
+		"part2.csv": "name\nbar",
+		"part3.csv": "baz\n",
+		"part1.bin": "ab",
+		"part2.bin": "cd",
+	}
+	data := []struct {
+		desc string
+		d    map[string]interface{}
+		want string
+	}{
+		{"Tabular", map[string]interface{}{"name": "foo", "path": []interface{}{"part1.csv", "part2.csv", "part3.csv"}}, "name\nfoo\n\nbar\nbaz\n"},
+		{"TabularNoHeader", map[string]interface{}{"name": "foo", "path": []interface{}{"part1.csv", "part2.csv"}, "dialect": map[string]interface{}{"header": false}}, "name\nfoo\n\nname\nbar"},
+		{"NonTabular", map[string]interface{}{"name": "foo", "path": []interface{}{"part1.bin", "part2.bin"}}, "abcd"},
+		{"SinglePart", map[string]interface{}{"name": "foo", "path": "part1.csv"}, "name\nfoo\n"},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			rc, err := newTestResource(t, d.d, files).ReadCloser()
+			is.NoErr(err)
+			defer rc.Close()
+			contents, err := ioutil.ReadAll(rc)
+			is.NoErr(err)
+			is.Equal(string(contents), d.want)
+		})
+	}
+	t.Run("Lazy", func(t *testing.T) {
+		is := is.New(t)
+		var opened []string
+		r := newTestResource(t, map[string]interface{}{"name": "foo", "path": []interface{}{"part1.bin", "part2.bin"}}, files)
+		open := r.open
+		r.open = func(name string) (io.ReadCloser, error) {
+			opened = append(opened, name)
+			return open(name)
+		}
+		rc, err := r.ReadCloser()
+		is.NoErr(err)
+		is.Equal(len(opened), 0)
+		b := make([]byte, 1)
+		_, err = io.ReadFull(rc, b)
+		is.NoErr(err)
+		is.Equal(opened, []string{"part1.bin"})
+
+		// Closing must stop the streaming.
+		is.NoErr(rc.Close())
+		n, err := rc.Read(b)
+		is.Equal(n, 0)
+		is.Equal(err, io.EOF)
+		is.Equal(opened, []string{"part1.bin"})
+	})
+}
+
+func TestResource_ReadObjects(t *testing.T) {
+	want := []map[string]interface{}{{"foo": "1234"}, {"foo": "5678"}}
+	t.Run("InlineArray", func(t *testing.T) {
+		is := is.New(t)
+		res, err := NewResourceFromString(`{"name": "ids", "data": [{"foo":"1234"}, {"foo":"5678"}], "profile":"data-resource"}`, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		objs, err := res.ReadObjects()
+		is.NoErr(err)
+		is.Equal(objs, want)
+	})
+	t.Run("InlineString", func(t *testing.T) {
+		is := is.New(t)
+		res, err := NewResourceFromString(`{"name": "ids", "data": "[{\"foo\":\"1234\"}, {\"foo\":\"5678\"}]", "format":"json", "profile":"data-resource"}`, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		objs, err := res.ReadObjects()
+		is.NoErr(err)
+		is.Equal(objs, want)
+	})
+	t.Run("Local", func(t *testing.T) {
+		is := is.New(t)
+		dir, err := ioutil.TempDir("", "resource_readobjects")
+		is.NoErr(err)
+		defer os.RemoveAll(dir)
+		is.NoErr(ioutil.WriteFile(filepath.Join(dir, "ids.json"), []byte(`[{"foo":"1234"}, {"foo":"5678"}]`), 0666))
+
+		res, err := NewResource(map[string]interface{}{"name": "ids", "path": "ids.json"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		res.basePath = dir
+		objs, err := res.ReadObjects()
+		is.NoErr(err)
+		is.Equal(objs, want)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		data := []struct {
+			desc string
+			res  string
+			want ObjectsError
+		}{
+			{"NotArray", `{"name": "ids", "data": {"foo":"1234"}, "profile":"data-resource"}`, ObjectsError{Index: -1, Type: "map[string]interface {}"}},
+			{"NotObject", `{"name": "ids", "data": [{"foo":"1234"}, 1], "profile":"data-resource"}`, ObjectsError{Index: 1, Type: "float64"}},
+			{"StringNotArray", `{"name": "ids", "data": "\"foo\"", "format":"json", "profile":"data-resource"}`, ObjectsError{Index: -1, Type: "string"}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				res, err := NewResourceFromString(d.res, validator.MustInMemoryRegistry())
+				is.NoErr(err)
+				_, err = res.ReadObjects()
+				objErr, ok := err.(*ObjectsError)
+				is.True(ok)
+				is.Equal(*objErr, d.want)
+			})
+		}
+	})
+	t.Run("InvalidJSONString", func(t *testing.T) {
+		is := is.New(t)
+		res, err := NewResourceFromString(`{"name": "ids", "data": "foo", "format":"json", "profile":"data-resource"}`, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		_, err = res.ReadObjects()
+		var syntaxErr *json.SyntaxError
+		is.True(errors.As(err, &syntaxErr))
+	})
+	t.Run("InlineArrayCopy", func(t *testing.T) {
+		is := is.New(t)
+		res, err := NewResourceFromString(`{"name": "ids", "data": [{"foo":"1234"}], "profile":"data-resource"}`, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		objs, err := res.ReadObjects()
+		is.NoErr(err)
+		objs[0]["foo"] = "changed"
+		objs, err = res.ReadObjects()
+		is.NoErr(err)
+		is.Equal(objs[0]["foo"], "1234")
+		is.Equal(res.Descriptor()["data"], []interface{}{map[string]interface{}{"foo": "1234"}})
+	})
+}
+
+func TestResource_ReadRows(t *testing.T) {
+	data := []struct {
+		desc    string
+		data    string
+		dialect interface{}
+		want    [][]string
+	}{
+		{"NoDialect", "a,b\n1,2\n3,4", nil, [][]string{{"1", "2"}, {"3", "4"}}},
+		{"Delimiter", "a;b\n1;2", map[string]interface{}{"delimiter": ";"}, [][]string{{"1", "2"}}},
+		{"NoHeader", "1,2\n3,4", map[string]interface{}{"header": false}, [][]string{{"1", "2"}, {"3", "4"}}},
+		{"SkipInitialSpace", "a, b\n1, 2", map[string]interface{}{"skipInitialSpace": true}, [][]string{{"1", "2"}}},
+		{"ConsiderInitialSpace", "a, b\n1, 2", map[string]interface{}{"skipInitialSpace": false}, [][]string{{"1", " 2"}}},
+		{"QuoteChar", "a,b\n'1',x\"y", map[string]interface{}{"quoteChar": "'"}, [][]string{{"'1'", "x\"y"}}},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			desc := map[string]interface{}{"name": "foo", "format": "csv", "data": d.data}
+			if d.dialect != nil {
+				desc["dialect"] = d.dialect
+			}
+			r, err := NewResource(desc, validator.MustInMemoryRegistry())
+			is.NoErr(err)
+			rows, err := r.ReadRows()
+			is.NoErr(err)
+			is.Equal(rows, d.want)
+		})
+	}
+	t.Run("Local", func(t *testing.T) {
+		is := is.New(t)
+		dir, err := ioutil.TempDir("", "resource_readrows")
+		is.NoErr(err)
+		defer os.RemoveAll(dir)
+		is.NoErr(ioutil.WriteFile(filepath.Join(dir, "foo.csv"), []byte("1\t2\n3\t4"), 0666))
+
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": "foo.csv", "dialect": map[string]interface{}{"delimiter": "\t", "header": false}}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		r.basePath = dir
+		rows, err := r.ReadRows()
+		is.NoErr(err)
+		is.Equal(rows, [][]string{{"1", "2"}, {"3", "4"}})
+	})
+	t.Run("NonTabular", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": "foo.bin"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		_, err = r.ReadRows()
+		is.True(err != nil)
+	})
+}
+
+type closeCounter struct {
+	io.Reader
+	closed int
+}
+
+func (c *closeCounter) Close() error {
+	c.closed++
+	return nil
+}
+
+// open can be used as the resource openFunc, so every path is read from c.
+func (c *closeCounter) open(string) (io.ReadCloser, error) {
+	return c, nil
+}
+
+func TestResource_Iterate(t *testing.T) {
+	t.Run("Rows", func(t *testing.T) {
+		is := is.New(t)
+		r := newTestResource(t, map[string]interface{}{"name": "foo", "path": "foo.csv"}, nil)
+		rc := &closeCounter{Reader: strings.NewReader("a,b\n1,2\n3,4")}
+		r.open = rc.open
+		iter, err := r.Iterate()
+		is.NoErr(err)
+		var rows [][]string
+		for iter.Next() {
+			rows = append(rows, iter.Row())
+		}
+		is.NoErr(iter.Err())
+		is.Equal(rows, [][]string{{"1", "2"}, {"3", "4"}})
+		is.Equal(rc.closed, 1) // closed when exhausted
+		is.True(!iter.Next())
+		is.NoErr(iter.Close())
+		is.Equal(rc.closed, 1)
+	})
+	t.Run("Close", func(t *testing.T) {
+		is := is.New(t)
+		r := newTestResource(t, map[string]interface{}{"name": "foo", "path": "foo.csv"}, nil)
+		rc := &closeCounter{Reader: strings.NewReader("a\n1\n2")}
+		r.open = rc.open
+		iter, err := r.Iterate()
+		is.NoErr(err)
+		is.True(iter.Next())
+		is.Equal(iter.Row(), []string{"1"})
+		is.NoErr(iter.Close())
+		is.Equal(rc.closed, 1)
+		is.True(!iter.Next())
+		is.NoErr(iter.Err())
+	})
+	t.Run("MalformedRow", func(t *testing.T) {
+		is := is.New(t)
+		r := newTestResource(t, map[string]interface{}{"name": "foo", "path": "foo.csv"}, nil)
+		rc := &closeCounter{Reader: strings.NewReader("a,b\n1,2\n3")}
+		r.open = rc.open
+		iter, err := r.Iterate()
+		is.NoErr(err)
+		is.True(iter.Next())
+		is.True(!iter.Next())
+		is.True(iter.Err() != nil)
+		is.Equal(rc.closed, 1)
+	})
+	t.Run("NDJSON", func(t *testing.T) {
+		is := is.New(t)
+		r := newTestResource(t, map[string]interface{}{"name": "foo", "path": "foo.ndjson", "format": "ndjson"}, nil)
+		rc := &closeCounter{Reader: strings.NewReader("{\"a\":1}\n{\"a\":2}\n")}
+		r.open = rc.open
+		iter, err := r.Iterate()
+		is.NoErr(err)
+		var rows [][]string
+		for iter.Next() {
+			rows = append(rows, iter.Row())
+		}
+		is.NoErr(iter.Err())
+		is.Equal(rows, [][]string{{"1"}, {"2"}})
+		is.Equal(rc.closed, 1)
+	})
+	t.Run("NonTabular", func(t *testing.T) {
+		is := is.New(t)
+		r := newTestResource(t, map[string]interface{}{"name": "foo", "path": "foo.bin"}, map[string]string{"foo.bin": ""})
+		_, err := r.Iterate()
+		is.True(err != nil)
+	})
+}
+
+func TestResource_ReadObjectsTyped(t *testing.T) {
+	fields := []interface{}{
+		map[string]interface{}{"name": "id", "type": "integer"},
+		map[string]interface{}{"name": "price", "type": "number"},
+		map[string]interface{}{"name": "available", "type": "boolean", "trueValues": []interface{}{"Y"}, "falseValues": []interface{}{"N"}},
+		map[string]interface{}{"name": "day", "type": "date", "format": "%d/%m/%Y"},
+		map[string]interface{}{"name": "at", "type": "datetime"},
+	}
+	t.Run("Valid", func(t *testing.T) {
+		is := is.New(t)
+		r := newTestResource(t, map[string]interface{}{"name": "foo", "format": "csv", "data": "id,price,available,day,at\n1,2.5,Y,25/12/2020,2020-12-25T10:00:00Z\n2,,N,,", "schema": map[string]interface{}{"fields": fields}}, nil)
+		objs, err := r.ReadObjectsTyped()
+		is.NoErr(err)
+		is.Equal(objs, []map[string]interface{}{
+			{"id": int64(1), "price": 2.5, "available": true, "day": time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC), "at": time.Date(2020, 12, 25, 10, 0, 0, 0, time.UTC)},
+			{"id": int64(2), "price": nil, "available": false, "day": nil, "at": nil},
+		})
+	})
+	t.Run("MissingValues", func(t *testing.T) {
+		is := is.New(t)
+		r := newTestResource(t, map[string]interface{}{"name": "foo", "format": "csv", "data": "id,price\nNA,\n", "schema": map[string]interface{}{"fields": fields[:2], "missingValues": []interface{}{"NA"}}}, nil)
+		_, err := r.ReadObjectsTyped()
+		is.True(err != nil) // "" is not a missing value anymore.
+
+		r = newTestResource(t, map[string]interface{}{"name": "foo", "format": "csv", "data": "id,price\nNA,NA\n", "schema": map[string]interface{}{"fields": fields[:2], "missingValues": []interface{}{"NA"}}}, nil)
+		objs, err := r.ReadObjectsTyped()
+		is.NoErr(err)
+		is.Equal(objs, []map[string]interface{}{{"id": nil, "price": nil}})
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			data  string
+			row   int
+			field string
+		}{
+			{"Integer", "id\n1\nfoo", 1, "id"},
+			{"Boolean", "id,price,available\n1,1,true", 0, "available"},
+			{"Date", "id,price,available,day\n1,1,Y,2020-12-25", 0, "day"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				header := strings.SplitN(d.data, "\n", 2)[0]
+				r := newTestResource(t, map[string]interface{}{"name": "foo", "format": "csv", "data": d.data, "schema": map[string]interface{}{"fields": fields[:strings.Count(header, ",")+1]}}, nil)
+				_, err := r.ReadObjectsTyped()
+				var castErr *CastError
+				is.True(errors.As(err, &castErr))
+				is.Equal(castErr.Row, d.row)
+				is.Equal(castErr.Field, d.field)
+			})
+		}
+	})
+	t.Run("RequiredMissing", func(t *testing.T) {
+		is := is.New(t)
+		r := newTestResource(t, map[string]interface{}{"name": "foo", "format": "csv", "data": "id,price\n,1", "schema": map[string]interface{}{"fields": []interface{}{map[string]interface{}{"name": "id", "type": "integer", "constraints": map[string]interface{}{"required": true}}, fields[1]}}}, nil)
+		_, err := r.ReadObjectsTyped()
+		is.True(err != nil)
+	})
+	t.Run("NoSchema", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "format": "csv", "data": "id\n1"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		_, err = r.ReadObjectsTyped()
+		is.True(err != nil)
+	})
+	t.Run("CellCountMismatch", func(t *testing.T) {
+		is := is.New(t)
+		r := newTestResource(t, map[string]interface{}{"name": "foo", "format": "csv", "data": "id,price\n1", "schema": map[string]interface{}{"fields": fields[:2]}}, nil)
+		_, err := r.ReadObjectsTyped()
+		is.True(err != nil)
+	})
+	t.Run("PrimaryKey", func(t *testing.T) {
+		data := []struct {
+			desc      string
+			data      string
+			pk        interface{}
+			row       int
+			nullField string
+			firstRow  int
+		}{
+			{"Duplicate", "id,price\n1,1\n2,1\n1,2", "id", 2, "", 0},
+			{"Null", "id,price\n1,1\n2,", []interface{}{"id", "price"}, 1, "price", 0},
+			{"CompositeDuplicate", "id,price\n1,1\n1,2\n2,1\n1,2", []interface{}{"id", "price"}, 3, "", 1},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				r := newTestResource(t, map[string]interface{}{"name": "foo", "format": "csv", "data": d.data, "schema": map[string]interface{}{"fields": fields[:2], "primaryKey": d.pk}}, nil)
+				_, err := r.ReadObjectsTyped()
+				is.NoErr(err) // Primary keys are only checked on demand.
+				_, err = r.ReadObjectsTyped(WithPrimaryKeyCheck())
+				var pkErr *PrimaryKeyError
+				is.True(errors.As(err, &pkErr))
+				is.Equal(pkErr.Row, d.row)
+				is.Equal(pkErr.NullField, d.nullField)
+				is.Equal(pkErr.FirstRow, d.firstRow)
+			})
+		}
+		t.Run("Valid", func(t *testing.T) {
+			is := is.New(t)
+			r := newTestResource(t, map[string]interface{}{"name": "foo", "format": "csv", "data": "id,price\n1,1\n2,1", "schema": map[string]interface{}{"fields": fields[:2], "primaryKey": "id"}}, nil)
+			objs, err := r.ReadObjectsTyped(WithPrimaryKeyCheck())
+			is.NoErr(err)
+			is.Equal(len(objs), 2)
+		})
+	})
+}
+
+func TestResource_Dialect(t *testing.T) {
+	is := is.New(t)
+	r, err := NewResource(map[string]interface{}{"name": "foo", "path": "foo.csv", "dialect": map[string]interface{}{"delimiter": ";", "quoteChar": "'", "doubleQuote": false}}, validator.MustInMemoryRegistry())
+	is.NoErr(err)
+	is.Equal(r.Dialect(), Dialect{Delimiter: ';', SkipInitialSpace: true, Header: true, DoubleQuote: false, QuoteChar: '\''})
+
+	r, err = NewResource(map[string]interface{}{"name": "foo", "path": "foo.csv"}, validator.MustInMemoryRegistry())
+	is.NoErr(err)
+	is.Equal(r.Dialect(), defaultDialect)
+}
+
+func TestResource_DialectReference(t *testing.T) {
+	dialectStr := `{"delimiter": ";", "header": true}`
+	t.Run("LocalFile", func(t *testing.T) {
+		is := is.New(t)
+		dir, err := ioutil.TempDir("", "datapackage_dialect")
+		is.NoErr(err)
+		defer os.RemoveAll(dir)
+		is.NoErr(os.Mkdir(filepath.Join(dir, "dialects"), os.ModePerm))
+		is.NoErr(ioutil.WriteFile(filepath.Join(dir, "dialects", "semicolon.json"), []byte(dialectStr), 0666))
+		is.NoErr(ioutil.WriteFile(filepath.Join(dir, "data.csv"), []byte("name;age\nfoo;42"), 0666))
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": "data.csv", "dialect": "dialects/semicolon.json"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		r.basePath = dir
+		rows, err := r.ReadRows()
+		is.NoErr(err)
+		is.Equal(rows, [][]string{{"foo", "42"}})
+		contents, err := r.ReadAll()
+		is.NoErr(err)
+		is.Equal(contents, [][]string{{"foo", "42"}})
+		is.Equal(r.Dialect().Delimiter, ';')
+		// The descriptor keeps the reference.
+		is.Equal(r.Descriptor()["dialect"], "dialects/semicolon.json")
+	})
+	t.Run("Remote", func(t *testing.T) {
+		is := is.New(t)
+		requests := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/semicolon.json":
+				requests++
+				fmt.Fprint(w, dialectStr)
+			default:
+				fmt.Fprint(w, "name;age\nfoo;42")
+			}
+		}))
+		defer ts.Close()
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": ts.URL + "/data.csv", "format": "csv", "dialect": ts.URL + "/semicolon.json"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		for i := 0; i < 2; i++ {
+			rows, err := r.ReadRows()
+			is.NoErr(err)
+			is.Equal(rows, [][]string{{"foo", "42"}})
+		}
+		// The loaded dialect is cached.
+		is.Equal(requests, 1)
+	})
+	t.Run("ConcurrentReaders", func(t *testing.T) {
+		is := is.New(t)
+		var requests int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/semicolon.json":
+				atomic.AddInt32(&requests, 1)
+				fmt.Fprint(w, dialectStr)
+			case "/schema.json":
+				atomic.AddInt32(&requests, 1)
+				fmt.Fprint(w, `{"fields": [{"name": "name", "type": "string"}, {"name": "age", "type": "integer"}]}`)
+			default:
+				fmt.Fprint(w, "name;age\nfoo;42")
+			}
+		}))
+		defer ts.Close()
+		r := &Resource{
+			descriptor: map[string]interface{}{"name": "foo", "path": ts.URL + "/data.csv", "format": "csv", "dialect": ts.URL + "/semicolon.json", "schema": ts.URL + "/schema.json"},
+			path:       []string{ts.URL + "/data.csv"},
+			name:       "foo",
+		}
+		errs := make(chan error, 8)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := r.ReadAll(); err != nil {
+					errs <- err
+				}
+				if _, err := r.Schema(); err != nil {
+					errs <- err
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			is.NoErr(err)
+		}
+		// Both references are loaded once.
+		is.Equal(atomic.LoadInt32(&requests), int32(2))
+	})
+	t.Run("Missing", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "data": "name;age\nfoo;42", "format": "csv", "dialect": "missing.json"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		_, err = r.ReadAll()
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), "missing.json"))
+	})
+	t.Run("Unparsable", func(t *testing.T) {
+		is := is.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "not a dialect")
+		}))
+		defer ts.Close()
+		r, err := NewResource(map[string]interface{}{"name": "foo", "data": "name;age\nfoo;42", "format": "csv", "dialect": ts.URL + "/dialect.json"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		_, err = r.ReadRows()
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), ts.URL+"/dialect.json"))
+	})
+	t.Run("InvalidReference", func(t *testing.T) {
+		for _, p := range []string{"../semicolon.json", "/etc/semicolon.json", "ftp://example.com/semicolon.json"} {
+			is := is.New(t)
+			_, err := NewResource(map[string]interface{}{"name": "foo", "path": "data.csv", "dialect": p}, validator.MustInMemoryRegistry())
+			is.True(err != nil)
+			vErr, ok := err.(ValidationError)
+			is.True(ok)
+			is.Equal(vErr.Field, "/dialect")
+		}
+	})
 }
 
 func TestResource_ReadColumn(t *testing.T) {
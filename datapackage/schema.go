@@ -2,7 +2,9 @@ package datapackage
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -12,10 +14,14 @@ import (
 	"github.com/frictionlessdata/tableschema-go/schema"
 )
 
-func loadSchema(p string) (map[string]interface{}, error) {
+func loadSchema(ctx context.Context, p string, client *http.Client) (map[string]interface{}, error) {
 	var reader io.Reader
 	if strings.HasPrefix(p, "http") {
-		resp, err := http.Get(p)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
 		if err != nil {
 			return nil, err
 		}
@@ -33,8 +39,36 @@ func loadSchema(p string) (map[string]interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	_, err = schema.Read(bytes.NewBuffer(buf))
+	return parseSchema(buf)
+}
+
+// loadRelativeSchema loads the schema at the passed-in path, which is resolved in the same
+// way as resource data paths.
+func loadRelativeSchema(ctx context.Context, p, basePath string, open openFunc, client *http.Client) (map[string]interface{}, error) {
+	buf, err := readRelative(ctx, p, basePath, open, client)
+	if err != nil {
+		return nil, err
+	}
+	return parseSchema(buf)
+}
+
+// loadResourceSchema loads the schema of the resource with the passed-in name as loadRelativeSchema
+// does. Errors name the resolved location of the schema.
+func loadResourceSchema(ctx context.Context, p, name, basePath string, open openFunc, client *http.Client) (map[string]interface{}, error) {
+	sch, err := loadRelativeSchema(ctx, p, basePath, open, client)
 	if err != nil {
+		loc := p
+		if !strings.HasPrefix(p, "http") && open == nil && basePath != "" {
+			loc = joinPaths(basePath, p)
+		}
+		return nil, fmt.Errorf("error loading schema %s of resource %s: %w", loc, name, err)
+	}
+	return sch, nil
+}
+
+// parseSchema checks whether buf holds a valid table schema and returns it as a map.
+func parseSchema(buf []byte) (map[string]interface{}, error) {
+	if _, err := schema.Read(bytes.NewBuffer(buf)); err != nil {
 		return nil, err
 	}
 	var ret map[string]interface{}
@@ -43,3 +77,70 @@ func loadSchema(p string) (map[string]interface{}, error) {
 	}
 	return ret, nil
 }
+
+// foreignKey is a schema foreign key. An empty resource references the resource itself.
+type foreignKey struct {
+	fields    []string
+	resource  string
+	refFields []string
+}
+
+// parseForeignKeys parses the foreignKeys property of the passed-in schema, which could be either
+// an array of foreign keys, as in the specification, or a single foreign key.
+func parseForeignKeys(sch map[string]interface{}) ([]foreignKey, error) {
+	var fkSlice []interface{}
+	switch fks := sch["foreignKeys"].(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		fkSlice = fks
+	case map[string]interface{}:
+		fkSlice = []interface{}{fks}
+	default:
+		return nil, fmt.Errorf("foreignKeys MUST be an array:%v", fks)
+	}
+	fks := make([]foreignKey, len(fkSlice))
+	for i, fkI := range fkSlice {
+		fkMap, ok := fkI.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("foreign key %d MUST be an object:%v", i, fkI)
+		}
+		ref, _ := fkMap["reference"].(map[string]interface{})
+		if ref == nil {
+			return nil, fmt.Errorf("foreign key %d MUST have a reference object", i)
+		}
+		fields, err := parseKeyFields(fkMap["fields"])
+		if err != nil {
+			return nil, fmt.Errorf("foreign key %d fields: %w", i, err)
+		}
+		refFields, err := parseKeyFields(ref["fields"])
+		if err != nil {
+			return nil, fmt.Errorf("foreign key %d reference fields: %w", i, err)
+		}
+		if len(fields) != len(refFields) {
+			return nil, fmt.Errorf("foreign key %d MUST have as many fields as reference fields", i)
+		}
+		resource, _ := ref["resource"].(string)
+		fks[i] = foreignKey{fields: fields, resource: resource, refFields: refFields}
+	}
+	return fks, nil
+}
+
+// parseKeyFields parses key fields, which could be either a field name or an array of field names.
+func parseKeyFields(f interface{}) ([]string, error) {
+	switch v := f.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		fields := make([]string, len(v))
+		for i, nameI := range v {
+			name, ok := nameI.(string)
+			if !ok {
+				return nil, fmt.Errorf("field names MUST be strings:%v", nameI)
+			}
+			fields[i] = name
+		}
+		return fields, nil
+	}
+	return nil, fmt.Errorf("fields MUST be either a string or an array of strings:%v", f)
+}
@@ -0,0 +1,124 @@
+package datapackage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frictionlessdata/tableschema-go/csv"
+	"github.com/frictionlessdata/tableschema-go/schema"
+	"github.com/frictionlessdata/tableschema-go/table"
+)
+
+// TabularResource gives access to the contents of a tabular resource. See Resource.AsTabular.
+type TabularResource struct {
+	r *Resource
+	// Schema used to cast rows. Nil if the resource has no schema.
+	sch *schema.Schema
+}
+
+// AsTabular returns the tabular view of the resource. An error is returned if the resource is
+// not tabular (see Tabular) or if its schema can not be loaded.
+func (r *Resource) AsTabular() (*TabularResource, error) {
+	if !r.Tabular() {
+		return nil, fmt.Errorf("resource %s is not tabular", r.name)
+	}
+	t := &TabularResource{r: r}
+	if r.descriptor[schemaProp] != nil {
+		sch, err := r.castSchema()
+		if err != nil {
+			return nil, err
+		}
+		t.sch = sch
+	}
+	return t, nil
+}
+
+// Resource returns the underlying resource.
+func (t *TabularResource) Resource() *Resource {
+	return t.r
+}
+
+// Headers returns the column names, read from the header row. If the contents have no header row
+// (see Dialect), the schema field names are returned.
+func (t *TabularResource) Headers() ([]string, error) {
+	opts, err := t.opts()
+	if err != nil {
+		return nil, err
+	}
+	tbl, err := t.r.GetTable(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if h := tbl.Headers(); len(h) > 0 {
+		return h, nil
+	}
+	if t.sch == nil {
+		return nil, fmt.Errorf("resource %s has neither a header row nor a schema", t.r.name)
+	}
+	headers := make([]string, len(t.sch.Fields))
+	for i, f := range t.sch.Fields {
+		headers[i] = f.Name
+	}
+	return headers, nil
+}
+
+// ReadRows returns the data rows, without the header row. See Resource.ReadRows.
+func (t *TabularResource) ReadRows() ([][]string, error) {
+	return t.r.ReadRows()
+}
+
+// Iter returns an iterator over the data rows, which skips the header row and casts rows using
+// the resource schema.
+func (t *TabularResource) Iter() (*TabularIterator, error) {
+	opts, err := t.opts()
+	if err != nil {
+		return nil, err
+	}
+	iter, err := t.r.Iter(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &TabularIterator{Iterator: iter, sch: t.sch, row: -1}, nil
+}
+
+// opts returns the options which make the table skip the header row. The dialect ones are added
+// by the resource, which loads the headers if the dialect says so.
+func (t *TabularResource) opts() ([]csv.CreationOpts, error) {
+	dMap, err := t.r.dialectDescriptor(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if dMap == nil {
+		return []csv.CreationOpts{csv.LoadHeaders()}, nil
+	}
+	return nil, nil
+}
+
+// TabularIterator iterates over the data rows of a tabular resource. Besides the raw rows, it
+// returns rows cast to the types of the schema fields.
+type TabularIterator struct {
+	table.Iterator
+	sch *schema.Schema
+	// Position of the current row, starting at 0.
+	row int
+}
+
+// Next advances the iterator to the next row. It returns false when the iteration stops, either by
+// reaching the end of the data or an error. See Err.
+func (i *TabularIterator) Next() bool {
+	if !i.Iterator.Next() {
+		return false
+	}
+	i.row++
+	return true
+}
+
+// Object returns the current row as an object keyed by the schema field names, with cells cast to
+// the types of their fields (see Resource.ReadObjectsTyped). A *CastError is returned if a cell can
+// not be cast, while ErrNoSchema is returned if the resource has no schema.
+func (i *TabularIterator) Object() (map[string]interface{}, error) {
+	if i.sch == nil {
+		return nil, ErrNoSchema
+	}
+	return castRow(i.sch, i.row, i.Row())
+}
@@ -0,0 +1,88 @@
+package datapackage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/frictionlessdata/datapackage-go/validator"
+	"github.com/matryer/is"
+)
+
+func TestResource_AsTabular(t *testing.T) {
+	sch := map[string]interface{}{"fields": []interface{}{
+		map[string]interface{}{"name": "name", "type": "string"},
+		map[string]interface{}{"name": "age", "type": "integer"},
+	}}
+	t.Run("Iter", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "data": "name,age\nfoo,42\nbar,84", "format": "csv", "schema": sch}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		tr, err := r.AsTabular()
+		is.NoErr(err)
+		is.Equal(tr.Resource(), r)
+		headers, err := tr.Headers()
+		is.NoErr(err)
+		is.Equal(headers, []string{"name", "age"})
+		rows, err := tr.ReadRows()
+		is.NoErr(err)
+		is.Equal(rows, [][]string{{"foo", "42"}, {"bar", "84"}})
+
+		iter, err := tr.Iter()
+		is.NoErr(err)
+		defer iter.Close()
+		var objs []map[string]interface{}
+		for iter.Next() {
+			obj, err := iter.Object()
+			is.NoErr(err)
+			objs = append(objs, obj)
+		}
+		is.NoErr(iter.Err())
+		is.Equal(objs, []map[string]interface{}{{"name": "foo", "age": int64(42)}, {"name": "bar", "age": int64(84)}})
+	})
+	t.Run("NoHeaderRow", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "data": "foo,42\nbar,x", "format": "csv", "schema": sch, "dialect": map[string]interface{}{"header": false}}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		tr, err := r.AsTabular()
+		is.NoErr(err)
+		headers, err := tr.Headers()
+		is.NoErr(err)
+		is.Equal(headers, []string{"name", "age"})
+		iter, err := tr.Iter()
+		is.NoErr(err)
+		defer iter.Close()
+		is.True(iter.Next())
+		is.Equal(iter.Row(), []string{"foo", "42"})
+		is.True(iter.Next())
+		_, err = iter.Object()
+		var castErr *CastError
+		is.True(errors.As(err, &castErr))
+		is.Equal(castErr.Row, 1)
+		is.Equal(castErr.Field, "age")
+	})
+	t.Run("NoSchema", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "data": "name,age\nfoo,42", "mediatype": "text/csv"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		tr, err := r.AsTabular()
+		is.NoErr(err)
+		headers, err := tr.Headers()
+		is.NoErr(err)
+		is.Equal(headers, []string{"name", "age"})
+		iter, err := tr.Iter()
+		is.NoErr(err)
+		defer iter.Close()
+		is.True(iter.Next())
+		is.Equal(iter.Row(), []string{"foo", "42"})
+		_, err = iter.Object()
+		is.True(errors.Is(err, ErrNoSchema))
+	})
+	t.Run("NotTabular", func(t *testing.T) {
+		is := is.New(t)
+		r, err := NewResource(map[string]interface{}{"name": "foo", "path": "foo.json"}, validator.MustInMemoryRegistry())
+		is.NoErr(err)
+		is.True(!r.Tabular())
+		_, err = r.AsTabular()
+		is.True(err != nil)
+	})
+}
@@ -1,6 +1,9 @@
 package validator
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/santhosh-tekuri/jsonschema"
 )
 
@@ -9,8 +12,58 @@ type jsonSchema struct {
 	schema *jsonschema.Schema
 }
 
-// IsValid checks the passed-in descriptor against the JSONSchema. If it returns
-// false, erros can be checked calling Errors() method.
+// IsValid checks the passed-in descriptor against the JSONSchema. If the descriptor is
+// not valid, the returned error is a SchemaErrors, which lists all violations found.
 func (v *jsonSchema) Validate(descriptor map[string]interface{}) error {
-	return v.schema.ValidateInterface(descriptor)
+	err := v.schema.ValidateInterface(descriptor)
+	if err == nil {
+		return nil
+	}
+	vErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return err
+	}
+	var errs SchemaErrors
+	collectSchemaErrors(vErr, &errs)
+	return errs
+}
+
+// SchemaError describes a violation of a profile by a descriptor.
+type SchemaError struct {
+	// Pointer is the JSON pointer to the invalid part of the descriptor, for instance "/resources/0/name".
+	Pointer string
+	// Message describes the violation.
+	Message string
+}
+
+func (e SchemaError) Error() string {
+	p := e.Pointer
+	if p == "" {
+		p = "/"
+	}
+	return fmt.Sprintf("%s: %s", p, e.Message)
+}
+
+// SchemaErrors is returned when a descriptor is not valid against a profile. It lists all
+// violations found during the validation.
+type SchemaErrors []SchemaError
+
+func (errs SchemaErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	return fmt.Sprintf("%d validation error(s) found:\n%s", len(errs), strings.Join(msgs, "\n"))
+}
+
+// collectSchemaErrors walks through the validation error tree, collecting the leaves,
+// which are the ones describing the actual violations.
+func collectSchemaErrors(err *jsonschema.ValidationError, errs *SchemaErrors) {
+	if len(err.Causes) == 0 {
+		*errs = append(*errs, SchemaError{Pointer: strings.TrimPrefix(err.InstancePtr, "#"), Message: err.Message})
+		return
+	}
+	for _, c := range err.Causes {
+		collectSchemaErrors(c, errs)
+	}
 }
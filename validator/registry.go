@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/frictionlessdata/datapackage-go/validator/profile_cache"
 	"github.com/santhosh-tekuri/jsonschema"
@@ -138,6 +139,53 @@ func FallbackRegistryLoader(loaders ...RegistryLoader) RegistryLoader {
 	}
 }
 
+type profileCache struct {
+	sync.Mutex
+	validators map[string]DescriptorValidator
+}
+
+// cachedRegistry is a registry which caches the validators returned by the underlying
+// registry, including the ones compiled from third-party profiles.
+type cachedRegistry struct {
+	registry Registry
+	cache    *profileCache
+}
+
+func (c *cachedRegistry) GetValidator(profile string) (DescriptorValidator, error) {
+	c.cache.Lock()
+	defer c.cache.Unlock()
+	if v, ok := c.cache.validators[profile]; ok {
+		return v, nil
+	}
+	var v DescriptorValidator
+	var err error
+	if isThirdPartyProfile(profile) {
+		v, err = compileThirdPartyProfile(profile)
+	} else {
+		v, err = c.registry.GetValidator(profile)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.cache.validators[profile] = v
+	return v, nil
+}
+
+// WithProfileCache returns a loader which caches the validators of the registry loaded by the
+// passed-in loader. Third-party profiles (URLs) are cached as well, keyed by their URL. The cache is
+// shared by all registries created by the returned loader, so reusing it avoids fetching and
+// compiling the same profile over and over again.
+func WithProfileCache(loader RegistryLoader) RegistryLoader {
+	cache := &profileCache{validators: make(map[string]DescriptorValidator)}
+	return func() (Registry, error) {
+		reg, err := loader()
+		if err != nil {
+			return nil, err
+		}
+		return &cachedRegistry{registry: reg, cache: cache}, nil
+	}
+}
+
 func unmarshalRegistryContents(buf []byte) (map[string]profileSpec, error) {
 	var specs []profileSpec
 	if err := json.Unmarshal(buf, &specs); err != nil {
@@ -1,9 +1,11 @@
 package validator
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/matryer/is"
@@ -30,6 +32,17 @@ func TestDescriptorValidator_IsValid(t *testing.T) {
 		is.NoErr(err)
 		is.True(v.Validate(map[string]interface{}{"resources": []interface{}{map[string]interface{}{"name": "res1"}}}) != nil)
 	})
+	t.Run("SchemaErrors", func(t *testing.T) {
+		is := is.New(t)
+		v, err := New("data-package", localLoader)
+		is.NoErr(err)
+		err = v.Validate(map[string]interface{}{"licenses": 1, "resources": []interface{}{map[string]interface{}{"name": "res1", "path": "foo.csv"}}})
+		errs, ok := err.(SchemaErrors)
+		is.True(ok)
+		is.Equal(len(errs), 1)
+		is.Equal(errs[0].Pointer, "/licenses")
+		is.Equal(errs[0].Error(), "/licenses: expected array, but got number")
+	})
 }
 
 func TestNew(t *testing.T) {
@@ -143,3 +156,116 @@ func serverForTests(contents string) *httptest.Server {
 		fmt.Fprintln(w, contents)
 	}))
 }
+
+func TestWithProfileCache(t *testing.T) {
+	t.Run("ThirdPartyProfile", func(t *testing.T) {
+		is := is.New(t)
+		hits := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits++
+			fmt.Fprintln(w, simpleSchema)
+		}))
+		defer ts.Close()
+		loader := WithProfileCache(localLoader)
+		for i := 0; i < 2; i++ {
+			reg, err := NewRegistry(loader)
+			is.NoErr(err)
+			is.NoErr(Validate(map[string]interface{}{"name": "foo"}, ts.URL, reg))
+			is.True(Validate(map[string]interface{}{"foo": "bar"}, ts.URL, reg) != nil)
+		}
+		is.Equal(hits, 1)
+	})
+	t.Run("RegisteredProfile", func(t *testing.T) {
+		is := is.New(t)
+		reg, err := NewRegistry(WithProfileCache(localLoader))
+		is.NoErr(err)
+		v1, err := reg.GetValidator("data-resource")
+		is.NoErr(err)
+		v2, err := reg.GetValidator("data-resource")
+		is.NoErr(err)
+		is.True(v1 == v2)
+	})
+	t.Run("InvalidProfileURL", func(t *testing.T) {
+		reg, err := NewRegistry(WithProfileCache(localLoader))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Validate(map[string]interface{}{"name": "foo"}, "http://127.0.0.1/bar", reg); err == nil {
+			t.Fatalf("want:err got:nil")
+		}
+	})
+}
+
+func TestResolveProfile(t *testing.T) {
+	loads := 0
+	load := func() (DescriptorValidator, error) {
+		loads++
+		return NewFromReader("http://example.com/profile.json", strings.NewReader(simpleSchema))
+	}
+	t.Run("CachedRegistry", func(t *testing.T) {
+		is := is.New(t)
+		loads = 0
+		loader := WithProfileCache(localLoader)
+		for i := 0; i < 3; i++ {
+			reg, err := NewRegistry(loader)
+			is.NoErr(err)
+			v, err := ResolveProfile(reg, "http://example.com/profile.json", load)
+			is.NoErr(err)
+			is.NoErr(v.Validate(map[string]interface{}{"name": "foo"}))
+		}
+		is.Equal(loads, 1)
+	})
+	t.Run("Registry", func(t *testing.T) {
+		is := is.New(t)
+		loads = 0
+		for i := 0; i < 2; i++ {
+			_, err := ResolveProfile(MustInMemoryRegistry(), "http://example.com/profile.json", load)
+			is.NoErr(err)
+		}
+		is.Equal(loads, 2)
+	})
+	t.Run("RegisteredProfile", func(t *testing.T) {
+		is := is.New(t)
+		loads = 0
+		_, err := ResolveProfile(MustInMemoryRegistry(), "data-resource", load)
+		is.NoErr(err)
+		is.Equal(loads, 0)
+	})
+}
+
+func TestValidate_ThirdPartyProfile(t *testing.T) {
+	is := is.New(t)
+	ts := serverForTests(simpleSchema)
+	defer ts.Close()
+	is.NoErr(Validate(map[string]interface{}{"name": "foo"}, ts.URL, MustInMemoryRegistry()))
+	is.True(Validate(map[string]interface{}{"foo": "bar"}, ts.URL, MustInMemoryRegistry()) != nil)
+}
+
+func TestNewFromReader(t *testing.T) {
+	is := is.New(t)
+	v, err := NewFromReader("http://example.com/profile.json", strings.NewReader(simpleSchema))
+	is.NoErr(err)
+	is.NoErr(v.Validate(map[string]interface{}{"name": "foo"}))
+	is.True(v.Validate(map[string]interface{}{"foo": "bar"}) != nil)
+
+	_, err = NewFromReader("http://example.com/profile.json", strings.NewReader("{"))
+	is.True(err != nil)
+}
+
+func TestLoadBundledProfile(t *testing.T) {
+	for _, name := range []string{"data-package", "tabular-data-package", "data-resource", "tabular-data-resource", "table-schema"} {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			buf, err := LoadBundledProfile(name)
+			is.NoErr(err)
+			var profile map[string]interface{}
+			is.NoErr(json.Unmarshal(buf, &profile))
+			is.True(profile["$schema"] != nil)
+		})
+	}
+	t.Run("Unknown", func(t *testing.T) {
+		is := is.New(t)
+		_, err := LoadBundledProfile("foo")
+		is.True(err != nil)
+	})
+}
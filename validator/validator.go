@@ -2,8 +2,10 @@ package validator
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
+	"github.com/frictionlessdata/datapackage-go/validator/profile_cache"
 	"github.com/santhosh-tekuri/jsonschema"
 )
 
@@ -35,12 +37,8 @@ func NewRegistry(loaders ...RegistryLoader) (Registry, error) {
 // New returns a new descriptor validator for the passed-in profile.
 func New(profile string, loaders ...RegistryLoader) (DescriptorValidator, error) {
 	// If it is a third-party schema. Directly referenced from the internet or local file.
-	if strings.HasPrefix(profile, "http") || strings.HasPrefix(profile, "file") {
-		schema, err := jsonschema.Compile(profile)
-		if err != nil {
-			return nil, err
-		}
-		return &jsonSchema{schema: schema}, nil
+	if isThirdPartyProfile(profile) {
+		return compileThirdPartyProfile(profile)
 	}
 	registry, err := NewRegistry(loaders...)
 	if err != nil {
@@ -49,11 +47,94 @@ func New(profile string, loaders ...RegistryLoader) (DescriptorValidator, error)
 	return registry.GetValidator(profile)
 }
 
+// NewFromReader returns a new descriptor validator for the JSON Schema profile read from r. The
+// profile URL identifies the schema and is used to resolve references relative to it.
+func NewFromReader(profile string, r io.Reader) (DescriptorValidator, error) {
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource(profile, r); err != nil {
+		return nil, fmt.Errorf("could not load profile %s:%q", profile, err)
+	}
+	schema, err := c.Compile(profile)
+	if err != nil {
+		return nil, fmt.Errorf("could not load profile %s:%q", profile, err)
+	}
+	return &jsonSchema{schema: schema}, nil
+}
+
+// LoadBundledProfile returns the JSON Schema of the profile shipped with the library which has the
+// passed-in identifier, for instance "data-package", "tabular-data-package" or "data-resource".
+// Bundled profiles are compiled into the library, so no file system or network access is needed.
+func LoadBundledProfile(name string) ([]byte, error) {
+	buf, err := profile_cache.FSByte(false, localRegistryPath)
+	if err != nil {
+		return nil, err
+	}
+	m, err := unmarshalRegistryContents(buf)
+	if err != nil {
+		return nil, err
+	}
+	spec, ok := m[name]
+	if !ok {
+		return nil, fmt.Errorf("there is no bundled profile %s", name)
+	}
+	return profile_cache.FSByte(false, spec.Schema)
+}
+
+func isThirdPartyProfile(profile string) bool {
+	return strings.HasPrefix(profile, "http") || strings.HasPrefix(profile, "file")
+}
+
+func compileThirdPartyProfile(profile string) (DescriptorValidator, error) {
+	schema, err := jsonschema.Compile(profile)
+	if err != nil {
+		return nil, fmt.Errorf("could not load profile %s:%q", profile, err)
+	}
+	return &jsonSchema{schema: schema}, nil
+}
+
+// getValidator returns the validator of the passed-in profile, which can be a registered
+// profile or a third-party profile URL.
+func getValidator(profile string, registry Registry) (DescriptorValidator, error) {
+	if c, ok := registry.(*cachedRegistry); ok {
+		return c.GetValidator(profile)
+	}
+	if isThirdPartyProfile(profile) {
+		return compileThirdPartyProfile(profile)
+	}
+	return registry.GetValidator(profile)
+}
+
+// ResolveProfile returns the validator of the passed-in profile from the registry. Third-party profiles
+// (URLs) are loaded by calling load, unless the registry caches validators (see WithProfileCache) and
+// already holds one for the profile. Validators loaded by cached registries are added to the cache. If
+// load is nil, third-party profiles are fetched and compiled as in Validate.
+func ResolveProfile(registry Registry, profile string, load func() (DescriptorValidator, error)) (DescriptorValidator, error) {
+	if load == nil || !isThirdPartyProfile(profile) {
+		return getValidator(profile, registry)
+	}
+	c, ok := registry.(*cachedRegistry)
+	if !ok {
+		return load()
+	}
+	c.cache.Lock()
+	defer c.cache.Unlock()
+	if v, ok := c.cache.validators[profile]; ok {
+		return v, nil
+	}
+	v, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.cache.validators[profile] = v
+	return v, nil
+}
+
 // Validate checks whether the descriptor the descriptor is valid against the passed-in profile/registry.
 // If the validation process generates multiple errors, their messages are coalesced.
 // It is a syntax-sugar around getting the validator from the registry and coalescing errors.
+// The profile could also be the URL of a third-party profile, which is fetched and compiled.
 func Validate(descriptor map[string]interface{}, profile string, registry Registry) error {
-	validator, err := registry.GetValidator(profile)
+	validator, err := getValidator(profile, registry)
 	if err != nil {
 		return fmt.Errorf("Invalid Schema (Profile:%s):%q", profile, err)
 	}